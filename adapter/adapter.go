@@ -14,8 +14,10 @@
 package adapter
 
 import (
+	"context"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 // Adapter provides a unified interface for sending HTTP responses.
@@ -34,6 +36,10 @@ type Adapter interface {
 	// Useful for sending files, images, or any binary content.
 	BinarySender(statusCode int, data []byte) error
 
+	// BytesSender sends raw bytes with the given content type.
+	// Used by reply to send payloads it encoded itself.
+	BytesSender(statusCode int, contentType string, data []byte) error
+
 	// TextSender sends plain text response with UTF-8 encoding.
 	TextSender(statusCode int, text string) error
 
@@ -43,24 +49,84 @@ type Adapter interface {
 
 	// StreamSender streams data from an io.Reader to the response.
 	// Useful for streaming large files, video, or real-time data.
+	// A Content-Length header set beforehand is kept. The reader is closed
+	// once copied if it implements io.Closer, which may be after the handler returns (e.g. fiber).
 	StreamSender(statusCode int, contentType string, reader io.Reader) error
 
+	// WriterSender sends the status and content type, then lets write produce the body.
+	// Useful for NDJSON, server-sent events and other incremental responses.
+	// write may run after the handler returns (e.g. fiber), so it must not use the adapter.
+	WriterSender(statusCode int, contentType string, write func(w StreamWriter) error) error
+
 	// RedirectSender sends a redirect response.
 	RedirectSender(statusCode int, url string)
 
 	// Write implements io.Writer interface, allowing direct writes to the response.
 	Write([]byte) (int, error)
 
+	// SetHeader sets a response header, replacing any existing values.
+	SetHeader(key, value string)
+
+	// AddHeader appends a value to a response header.
+	// Multiple Set-Cookie values are kept as separate headers.
+	AddHeader(key, value string)
+
+	// DelHeader removes all values of a response header.
+	DelHeader(key string)
+
+	// GetHeader returns the first value of a response header,
+	// or an empty string if the header is absent.
+	GetHeader(key string) string
+
+	// HeaderValues returns all values of a response header.
+	HeaderValues(key string) []string
+
 	// Header returns the HTTP headers that will be sent with the response.
-	// You can use this to set custom headers before sending the response.
+	// Changes to the returned map are not sent on adapters without a header map (e.g. fiber).
+	//
+	// Deprecated: use SetHeader, AddHeader, DelHeader, GetHeader and HeaderValues,
+	// which behave the same on every framework.
 	Header() http.Header
 
 	// Set status code to header
 	SetStatus(statusCode int)
 
+	// Method returns the HTTP method of the request.
+	Method() string
+
+	// Path returns the URL path of the request, without the query string.
+	Path() string
+
+	// Query returns the parsed URL query values of the request.
+	Query() url.Values
+
+	// RequestHeader returns the first value of the named request header,
+	// or an empty string if the header is absent.
+	RequestHeader(key string) string
+
+	// RemoteAddr returns the network address of the client that sent the request.
+	RemoteAddr() string
+
+	// Context returns the context of the request.
+	// Inside WriterSender write, it may be a context canceled when the stream fails.
+	Context() context.Context
+
 	// Get contexted value
 	Get(key any) (value any, ok bool)
 
 	// Set value to request context
 	Set(key, value any)
 }
+
+// StreamWriter is the response body handed to Adapter.WriterSender.
+type StreamWriter interface {
+	io.Writer
+
+	// Flush pushes buffered bytes to the client.
+	// It is a no-op if the response can not be flushed.
+	Flush() error
+
+	// SetTrailer sets an HTTP trailer sent after the body.
+	// It is a no-op on adapters without trailer support (e.g. fiber).
+	SetTrailer(key, value string)
+}
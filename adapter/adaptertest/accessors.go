@@ -0,0 +1,108 @@
+package adaptertest
+
+import (
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/chesta132/goreply/adapter"
+)
+
+// accessorTests lists requests and the values the request accessors must return for them.
+var accessorTests = []struct {
+	name   string
+	method string
+	target string
+	header http.Header
+
+	path        string     // Expected Path()
+	query       url.Values // Expected Query()
+	headerKey   string     // Request header read with RequestHeader
+	headerValue string     // Expected RequestHeader(headerKey)
+}{
+	{
+		name:   "Root",
+		method: http.MethodGet,
+		target: "/",
+		path:   "/",
+		query:  url.Values{},
+
+		headerKey: "X-Missing",
+	},
+	{
+		name:   "EncodedKeys",
+		method: http.MethodPatch,
+		target: "/users/42?tag%5B%5D=a&tag%5B%5D=b&first+name=Jane&flag&flag=",
+		header: http.Header{"Accept-Language": {"en, id;q=0.8"}},
+		path:   "/users/42",
+		query:  url.Values{"tag[]": {"a", "b"}, "first name": {"Jane"}, "flag": {"", ""}},
+
+		headerKey:   "Accept-Language",
+		headerValue: "en, id;q=0.8",
+	},
+	{
+		name:   "EscapedQuery",
+		method: http.MethodDelete,
+		target: "/search?q=a%20b&amp=%26&empty=",
+		path:   "/search",
+		query:  url.Values{"q": {"a b"}, "amp": {"&"}, "empty": {""}},
+
+		headerKey: "Accept",
+	},
+	{
+		name:   "HeaderCaseInsensitive",
+		method: http.MethodPut,
+		target: "/items/7",
+		header: http.Header{"X-Request-Id": {"req-1"}},
+		path:   "/items/7",
+		query:  url.Values{},
+
+		headerKey:   "x-request-id",
+		headerValue: "req-1",
+	},
+}
+
+// accessorCases builds a conformance check of the request accessors for each accessor test.
+func accessorCases() []testCase {
+	var tcs []testCase
+	for _, at := range accessorTests {
+		tcs = append(tcs, testCase{
+			name: "RequestAccessors/" + at.name,
+			request: func() *http.Request {
+				req := httptest.NewRequest(at.method, at.target, nil)
+				for key, values := range at.header {
+					req.Header[key] = values
+				}
+				return req
+			},
+			handle: func(t *testing.T, a adapter.Adapter) {
+				if got := a.Method(); got != at.method {
+					t.Errorf("Method() = %q, want %q", got, at.method)
+				}
+				if got := a.Path(); got != at.path {
+					t.Errorf("Path() = %q, want %q", got, at.path)
+				}
+				if got := a.Query(); !maps.EqualFunc(got, at.query, slices.Equal) {
+					t.Errorf("Query() = %v, want %v", got, at.query)
+				}
+				if got := a.RequestHeader(at.headerKey); got != at.headerValue {
+					t.Errorf("RequestHeader(%s) = %q, want %q", at.headerKey, got, at.headerValue)
+				}
+				if a.RemoteAddr() == "" {
+					t.Errorf("RemoteAddr() is empty")
+				}
+				if a.Context() == nil {
+					t.Errorf("Context() is nil")
+				}
+				a.SetStatus(http.StatusNoContent)
+			},
+			check: func(t *testing.T, res *http.Response, body []byte) {
+				expectStatus(t, res, http.StatusNoContent)
+			},
+		})
+	}
+	return tcs
+}
@@ -0,0 +1,66 @@
+// Package adaptertest provides a conformance suite for adapter.Adapter implementations.
+//
+// Every adapter must behave the same way regardless of the underlying framework:
+// status codes, content types, body bytes, header mutations, redirects, streaming,
+// request accessors and context values. Run the suite from the adapter tests by
+// providing a Serve function that dispatches a request to the framework.
+//
+// Example with net/http:
+//
+//	func TestConformance(t *testing.T) {
+//		adaptertest.Run(t, func(req *http.Request, handle func(adapter.Adapter)) (*http.Response, error) {
+//			w := httptest.NewRecorder()
+//			handle(nethttpadapter.AdaptHttp(w, req))
+//			return w.Result(), nil
+//		})
+//	}
+package adaptertest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/adapter"
+)
+
+// Serve dispatches req to the framework, calls handle with the adapter of the request
+// inside the framework handler, and returns the response the framework sent.
+type Serve func(req *http.Request, handle func(a adapter.Adapter)) (*http.Response, error)
+
+// testCase is a single conformance check.
+type testCase struct {
+	name    string
+	request func() *http.Request                                // Request to serve. Default: GET /
+	handle  func(t *testing.T, a adapter.Adapter)               // Runs inside the framework handler
+	check   func(t *testing.T, res *http.Response, body []byte) // Verifies the sent response
+}
+
+// Run runs the conformance suite against the adapter served by serve.
+// Each check runs as a subtest.
+func Run(t *testing.T, serve Serve) {
+	t.Helper()
+	for _, tc := range append(cases, accessorCases()...) {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.request != nil {
+				req = tc.request()
+			}
+
+			res, err := serve(req, func(a adapter.Adapter) {
+				tc.handle(t, a)
+			})
+			if err != nil {
+				t.Fatalf("serve: %v", err)
+			}
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			tc.check(t, res, body)
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package adaptertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/chesta132/goreply/adapter"
+)
+
+// contextKey is the key type of context values set by the suite.
+type contextKey struct{}
+
+// xmlItem is the XML document sent by the suite.
+type xmlItem struct {
+	XMLName xml.Name `xml:"item"`
+	ID      int      `xml:"id"`
+}
+
+// closeRecorder records whether the reader was closed.
+type closeRecorder struct {
+	io.Reader
+	closed atomic.Bool
+}
+
+// Close marks the reader as closed.
+func (c *closeRecorder) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+// cases lists the conformance checks run by Run.
+var cases = []testCase{
+	{
+		name: "JsonSender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if err := a.JsonSender(http.StatusCreated, map[string]string{"msg": "ok"}); err != nil {
+				t.Errorf("JsonSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusCreated)
+			expectMediaType(t, res, "application/json", false)
+			var got map[string]string
+			if err := json.Unmarshal(body, &got); err != nil || got["msg"] != "ok" {
+				t.Errorf("body = %q, want {\"msg\":\"ok\"}", body)
+			}
+		},
+	},
+	{
+		name: "JsonSenderEncodeError",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if err := a.JsonSender(http.StatusOK, make(chan int)); err == nil {
+				t.Errorf("JsonSender: expected error for unencodable data")
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {},
+	},
+	{
+		name: "XmlSender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if err := a.XmlSender(http.StatusOK, xmlItem{ID: 1}); err != nil {
+				t.Errorf("XmlSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusOK)
+			expectMediaType(t, res, "application/xml", false)
+			var got xmlItem
+			if err := xml.Unmarshal(body, &got); err != nil || got.ID != 1 {
+				t.Errorf("body = %q, want <item><id>1</id></item>", body)
+			}
+		},
+	},
+	{
+		name: "BinarySender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if err := a.BinarySender(http.StatusOK, []byte{0xFF, 0xD8, 0x00}); err != nil {
+				t.Errorf("BinarySender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusOK)
+			expectMediaType(t, res, "application/octet-stream", false)
+			expectBody(t, body, []byte{0xFF, 0xD8, 0x00})
+		},
+	},
+	{
+		name: "BytesSender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if err := a.BytesSender(http.StatusOK, "application/vnd.api+json", []byte(`{"data":[]}`)); err != nil {
+				t.Errorf("BytesSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusOK)
+			expectMediaType(t, res, "application/vnd.api+json", false)
+			expectBody(t, body, []byte(`{"data":[]}`))
+		},
+	},
+	{
+		name: "TextSender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if err := a.TextSender(http.StatusAccepted, "Hello!"); err != nil {
+				t.Errorf("TextSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusAccepted)
+			expectMediaType(t, res, "text/plain", true)
+			expectBody(t, body, []byte("Hello!"))
+		},
+	},
+	{
+		name: "HtmlSender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if err := a.HtmlSender(http.StatusOK, "<h1>Hi</h1>"); err != nil {
+				t.Errorf("HtmlSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusOK)
+			expectMediaType(t, res, "text/html", true)
+			expectBody(t, body, []byte("<h1>Hi</h1>"))
+		},
+	},
+	{
+		name: "StreamSender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			data := bytes.Repeat([]byte("stream"), 4096)
+			if err := a.StreamSender(http.StatusOK, "video/mp4", bytes.NewReader(data)); err != nil {
+				t.Errorf("StreamSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusOK)
+			expectMediaType(t, res, "video/mp4", false)
+			expectBody(t, body, bytes.Repeat([]byte("stream"), 4096))
+		},
+	},
+	{
+		name: "WriterSender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			err := a.WriterSender(http.StatusOK, "application/x-ndjson", func(w adapter.StreamWriter) error {
+				for _, line := range []string{"{\"n\":1}\n", "{\"n\":2}\n"} {
+					if _, err := io.WriteString(w, line); err != nil {
+						return err
+					}
+					if err := w.Flush(); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Errorf("WriterSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusOK)
+			expectMediaType(t, res, "application/x-ndjson", false)
+			expectBody(t, body, []byte("{\"n\":1}\n{\"n\":2}\n"))
+		},
+	},
+	{
+		name: "StreamSenderContentLength",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			a.SetHeader("Content-Length", "5")
+			if err := a.StreamSender(http.StatusPartialContent, "video/mp4", strings.NewReader("bytes")); err != nil {
+				t.Errorf("StreamSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusPartialContent)
+			if res.ContentLength != 5 {
+				t.Errorf("Content-Length: got %d, want 5", res.ContentLength)
+			}
+			expectBody(t, body, []byte("bytes"))
+		},
+	},
+	{
+		name: "StreamSenderClose",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			rc := &closeRecorder{Reader: strings.NewReader("closed")}
+			if err := a.StreamSender(http.StatusOK, "text/plain", rc); err != nil {
+				t.Errorf("StreamSender: %v", err)
+			}
+			// the body may be copied after the handler returns
+			t.Cleanup(func() {
+				if !rc.closed.Load() {
+					t.Error("StreamSender: reader was not closed")
+				}
+			})
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusOK)
+			expectBody(t, body, []byte("closed"))
+		},
+	},
+	{
+		name: "RedirectSender",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			a.RedirectSender(http.StatusMovedPermanently, "https://example.com/target")
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusMovedPermanently)
+			if got := res.Header.Get("Location"); got != "https://example.com/target" {
+				t.Errorf("Location = %q, want https://example.com/target", got)
+			}
+		},
+	},
+	{
+		name: "SetStatusWrite",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			a.SetHeader("Content-Type", "text/csv")
+			a.SetStatus(http.StatusAccepted)
+			if _, err := a.Write([]byte("a,b\n")); err != nil {
+				t.Errorf("Write: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusAccepted)
+			expectMediaType(t, res, "text/csv", false)
+			expectBody(t, body, []byte("a,b\n"))
+		},
+	},
+	{
+		name: "HeaderMutation",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			a.SetHeader("X-Set", "old")
+			a.SetHeader("X-Set", "new")
+			a.AddHeader("X-Multi", "1")
+			a.AddHeader("X-Multi", "2")
+			a.SetHeader("X-Deleted", "value")
+			a.DelHeader("X-Deleted")
+			a.AddHeader("Set-Cookie", (&http.Cookie{Name: "access", Value: "a"}).String())
+			a.AddHeader("Set-Cookie", (&http.Cookie{Name: "refresh", Value: "r"}).String())
+
+			if got := a.GetHeader("X-Set"); got != "new" {
+				t.Errorf("GetHeader(X-Set) = %q, want new", got)
+			}
+			if got := a.HeaderValues("X-Multi"); !slices.Equal(got, []string{"1", "2"}) {
+				t.Errorf("HeaderValues(X-Multi) = %q, want [1 2]", got)
+			}
+			if got := a.GetHeader("X-Deleted"); got != "" {
+				t.Errorf("GetHeader(X-Deleted) = %q, want empty", got)
+			}
+			if got := a.HeaderValues("Set-Cookie"); len(got) != 2 {
+				t.Errorf("HeaderValues(Set-Cookie) = %q, want 2 cookies", got)
+			}
+			if err := a.TextSender(http.StatusOK, "ok"); err != nil {
+				t.Errorf("TextSender: %v", err)
+			}
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusOK)
+			if got := res.Header.Get("X-Set"); got != "new" {
+				t.Errorf("X-Set = %q, want new", got)
+			}
+			if got := res.Header.Values("X-Multi"); !slices.Equal(got, []string{"1", "2"}) {
+				t.Errorf("X-Multi = %q, want [1 2]", got)
+			}
+			if got := res.Header.Get("X-Deleted"); got != "" {
+				t.Errorf("X-Deleted = %q, want empty", got)
+			}
+			cookies := map[string]string{}
+			for _, c := range res.Cookies() {
+				cookies[c.Name] = c.Value
+			}
+			if cookies["access"] != "a" || cookies["refresh"] != "r" {
+				t.Errorf("cookies = %v, want access=a and refresh=r", cookies)
+			}
+		},
+	},
+	{
+		name: "DeprecatedHeader",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			a.SetHeader("X-Set", "value")
+			a.AddHeader("Set-Cookie", (&http.Cookie{Name: "access", Value: "a"}).String())
+			a.AddHeader("Set-Cookie", (&http.Cookie{Name: "refresh", Value: "r"}).String())
+
+			header := a.Header()
+			if got := header.Get("X-Set"); got != "value" {
+				t.Errorf("Header().Get(X-Set) = %q, want value", got)
+			}
+			if got := header.Values("Set-Cookie"); len(got) != 2 {
+				t.Errorf("Header().Values(Set-Cookie) = %q, want 2 cookies", got)
+			}
+			a.SetStatus(http.StatusNoContent)
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusNoContent)
+			if got := len(res.Cookies()); got != 2 {
+				t.Errorf("cookies = %d, want 2", got)
+			}
+		},
+	},
+	{
+		name: "RequestView",
+		request: func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/users/42?tag=a&tag=b&q=go", nil)
+			req.Header.Set("Accept", "application/xml")
+			return req
+		},
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if got := a.Method(); got != http.MethodPost {
+				t.Errorf("Method() = %q, want POST", got)
+			}
+			if got := a.Path(); got != "/users/42" {
+				t.Errorf("Path() = %q, want /users/42", got)
+			}
+			query := a.Query()
+			if got := query["tag"]; !slices.Equal(got, []string{"a", "b"}) {
+				t.Errorf("Query()[tag] = %q, want [a b]", got)
+			}
+			if got := query.Get("q"); got != "go" {
+				t.Errorf("Query().Get(q) = %q, want go", got)
+			}
+			if got := a.RequestHeader("Accept"); got != "application/xml" {
+				t.Errorf("RequestHeader(Accept) = %q, want application/xml", got)
+			}
+			if got := a.RequestHeader("X-Missing"); got != "" {
+				t.Errorf("RequestHeader(X-Missing) = %q, want empty", got)
+			}
+			if a.RemoteAddr() == "" {
+				t.Errorf("RemoteAddr() is empty")
+			}
+			if a.Context() == nil {
+				t.Errorf("Context() is nil")
+			}
+			a.SetStatus(http.StatusNoContent)
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusNoContent)
+		},
+	},
+	{
+		name: "ContextValues",
+		handle: func(t *testing.T, a adapter.Adapter) {
+			if _, ok := a.Get(contextKey{}); ok {
+				t.Errorf("Get: value found before Set")
+			}
+			a.Set(contextKey{}, "value")
+			if got, ok := a.Get(contextKey{}); !ok || got != "value" {
+				t.Errorf("Get = %v, %v, want value, true", got, ok)
+			}
+			a.SetStatus(http.StatusNoContent)
+		},
+		check: func(t *testing.T, res *http.Response, body []byte) {
+			expectStatus(t, res, http.StatusNoContent)
+		},
+	},
+}
+
+// expectStatus verifies the response status code.
+func expectStatus(t *testing.T, res *http.Response, want int) {
+	t.Helper()
+	if res.StatusCode != want {
+		t.Errorf("status = %d, want %d", res.StatusCode, want)
+	}
+}
+
+// expectMediaType verifies the media type of the Content-Type header,
+// and its utf-8 charset if charset is true.
+func expectMediaType(t *testing.T, res *http.Response, want string, charset bool) {
+	t.Helper()
+	contentType := res.Header.Get("Content-Type")
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != want {
+		t.Errorf("Content-Type = %q, want %s", contentType, want)
+		return
+	}
+	if charset && !strings.EqualFold(params["charset"], "utf-8") {
+		t.Errorf("Content-Type = %q, want charset=utf-8", contentType)
+	}
+}
+
+// expectBody verifies the response body bytes.
+func expectBody(t *testing.T, body, want []byte) {
+	t.Helper()
+	if !bytes.Equal(body, want) {
+		t.Errorf("body = %q, want %q", truncate(body), truncate(want))
+	}
+}
+
+// truncate shortens long bodies in failure messages.
+func truncate(b []byte) []byte {
+	if len(b) > 64 {
+		return append(b[:64:64], "..."...)
+	}
+	return b
+}
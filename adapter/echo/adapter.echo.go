@@ -1,9 +1,12 @@
 package adapter
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/chesta132/goreply/adapter"
 	"github.com/labstack/echo/v4"
@@ -27,19 +30,76 @@ func AdaptEcho(c echo.Context) adapter.Adapter {
 	return &echoAdapter{ctx: c}
 }
 
+// SetHeader sets a response header, replacing any existing values.
+func (e *echoAdapter) SetHeader(key, value string) {
+	e.ctx.Response().Header().Set(key, value)
+}
+
+// AddHeader appends a value to a response header.
+func (e *echoAdapter) AddHeader(key, value string) {
+	e.ctx.Response().Header().Add(key, value)
+}
+
+// DelHeader removes a response header.
+func (e *echoAdapter) DelHeader(key string) {
+	e.ctx.Response().Header().Del(key)
+}
+
+// GetHeader returns the first value of a response header.
+func (e *echoAdapter) GetHeader(key string) string {
+	return e.ctx.Response().Header().Get(key)
+}
+
+// HeaderValues returns all values of a response header.
+func (e *echoAdapter) HeaderValues(key string) []string {
+	return e.ctx.Response().Header().Values(key)
+}
+
 // Header returns the response headers map.
+//
+// Deprecated: use SetHeader, AddHeader, DelHeader, GetHeader and HeaderValues.
 func (e *echoAdapter) Header() http.Header {
 	return e.ctx.Response().Header()
 }
 
 // Write writes raw bytes to the response.
 func (e *echoAdapter) Write(b []byte) (int, error) {
-	return e.ctx.Response().Writer.Write(b)
+	return e.ctx.Response().Write(b)
 }
 
 // Write status header
 func (g *echoAdapter) SetStatus(statusCode int) {
-	g.ctx.Response().Writer.WriteHeader(statusCode)
+	g.ctx.Response().WriteHeader(statusCode)
+}
+
+// Method returns the request method.
+func (e *echoAdapter) Method() string {
+	return e.ctx.Request().Method
+}
+
+// Path returns the request URL path.
+func (e *echoAdapter) Path() string {
+	return e.ctx.Request().URL.Path
+}
+
+// Query returns the parsed request query values.
+func (e *echoAdapter) Query() url.Values {
+	return e.ctx.QueryParams()
+}
+
+// RequestHeader returns the first value of the named request header.
+func (e *echoAdapter) RequestHeader(key string) string {
+	return e.ctx.Request().Header.Get(key)
+}
+
+// RemoteAddr returns the client network address.
+func (e *echoAdapter) RemoteAddr() string {
+	return e.ctx.Request().RemoteAddr
+}
+
+// Context returns the request context.
+func (e *echoAdapter) Context() context.Context {
+	return e.ctx.Request().Context()
 }
 
 // JsonSender writes JSON response with given status.
@@ -75,6 +135,17 @@ func (e *echoAdapter) BinarySender(statusCode int, data []byte) error {
 	return e.ctx.Blob(statusCode, "application/octet-stream", data)
 }
 
+// BytesSender writes raw bytes with given Content-Type.
+//
+// Please use reply to handle this sender.
+//
+// Example:
+//
+//	e.BytesSender(200, "application/json", []byte(`{"msg":"ok"}`)) // -> {"msg":"ok"}
+func (e *echoAdapter) BytesSender(statusCode int, contentType string, data []byte) error {
+	return e.ctx.Blob(statusCode, contentType, data)
+}
+
 // TextSender writes plain text response.
 //
 // Please use reply to handle this sender.
@@ -105,12 +176,57 @@ func (e *echoAdapter) HtmlSender(statusCode int, html string) error {
 //
 //	e.StreamSender(200, "video/mp4", fileReader) // streams MP4
 func (e *echoAdapter) StreamSender(statusCode int, contentType string, reader io.Reader) error {
+	if c, ok := reader.(io.Closer); ok {
+		defer c.Close()
+	}
 	e.ctx.Response().Header().Set("Content-Type", contentType)
 	e.ctx.Response().WriteHeader(statusCode)
-	_, err := io.Copy(e.ctx.Response().Writer, reader)
+	_, err := io.Copy(e.ctx.Response(), reader)
 	return err
 }
 
+// WriterSender writes status and Content-Type, then lets write produce the body.
+// Flush is a no-op if the response can not flush.
+//
+// Please use reply to handle this sender.
+//
+// Example:
+//
+//	e.WriterSender(200, "application/x-ndjson", func(w adapter.StreamWriter) error {
+//		io.WriteString(w, "{}\n")
+//		return w.Flush()
+//	})
+func (e *echoAdapter) WriterSender(statusCode int, contentType string, write func(w adapter.StreamWriter) error) error {
+	res := e.ctx.Response()
+	res.Header().Set("Content-Type", contentType)
+	res.WriteHeader(statusCode)
+	return write(&streamWriter{res: res, rc: http.NewResponseController(res)})
+}
+
+// streamWriter implements adapter.StreamWriter over an echo.Response.
+type streamWriter struct {
+	res *echo.Response
+	rc  *http.ResponseController
+}
+
+// Write writes p to the response body.
+func (s *streamWriter) Write(p []byte) (int, error) {
+	return s.res.Write(p)
+}
+
+// Flush sends buffered data to the client. It is a no-op if the response can not flush.
+func (s *streamWriter) Flush() error {
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// SetTrailer sets an HTTP trailer sent after the body.
+func (s *streamWriter) SetTrailer(key, value string) {
+	s.res.Header().Set(http.TrailerPrefix+key, value)
+}
+
 // RedirectSender sends a redirect response.
 //
 // Please use reply to handle this sender.
@@ -0,0 +1,22 @@
+package adapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/adapter"
+	"github.com/chesta132/goreply/adapter/adaptertest"
+	"github.com/labstack/echo/v4"
+)
+
+// TestConformance runs the adapter conformance suite against the echo adapter.
+func TestConformance(t *testing.T) {
+	adaptertest.Run(t, func(req *http.Request, handle func(adapter.Adapter)) (*http.Response, error) {
+		w := httptest.NewRecorder()
+		e := echo.New()
+		e.Any("/*", func(c echo.Context) error { handle(AdaptEcho(c)); return nil })
+		e.ServeHTTP(w, req)
+		return w.Result(), nil
+	})
+}
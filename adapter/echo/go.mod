@@ -17,3 +17,6 @@ require (
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.25.0 // indirect
 )
+
+// Build against the goreply module of this repository.
+replace github.com/chesta132/goreply => ../..
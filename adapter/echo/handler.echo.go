@@ -0,0 +1,53 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/labstack/echo/v4"
+)
+
+// ErrorHandler returns an echo.HTTPErrorHandler that renders errors as goreply error replies.
+// *echo.HTTPError keeps its status code, ErrorPayload is rendered as is,
+// and other errors are mapped by the errors registered on the client.
+//
+// Example:
+//
+//	e := echo.New()
+//	e.HTTPErrorHandler = adapter.ErrorHandler(Client)
+func ErrorHandler(client *reply.Client) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		rp := client.Use(AdaptEcho(c))
+		if rp.Sent() {
+			return
+		}
+
+		var he *echo.HTTPError
+		if _, isPayload := reply.AsErrorPayload(err); !isPayload && errors.As(err, &he) {
+			err = reply.StatusError(he.Code, httpErrorMessage(he), reply.WithCause(he.Internal))
+		}
+
+		if err := rp.Err(err).Fail(); err != nil {
+			c.Logger().Error(err)
+		}
+	}
+}
+
+// httpErrorMessage returns the message of an echo.HTTPError as string.
+func httpErrorMessage(he *echo.HTTPError) string {
+	switch m := he.Message.(type) {
+	case string:
+		return m
+	case error:
+		return m.Error()
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(m)
+	}
+}
@@ -0,0 +1,35 @@
+package adapter
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/labstack/echo/v4"
+)
+
+// Recover returns a middleware that recovers panics and replies with a goreply error envelope.
+// See reply.Reply.Recover.
+//
+// Example:
+//
+//	e := echo.New()
+//	e.Use(adapter.Recover(Client))
+func Recover(client *reply.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					if v == http.ErrAbortHandler {
+						panic(v)
+					}
+					err = client.Use(AdaptEcho(c)).Recover(v)
+					if errors.Is(err, reply.ErrAlreadySent) {
+						err = nil
+					}
+				}
+			}()
+			return next(c)
+		}
+	}
+}
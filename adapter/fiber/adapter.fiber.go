@@ -2,9 +2,13 @@ package adapter
 
 import (
 	"bufio"
+	"context"
 	"encoding/xml"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/chesta132/goreply/adapter"
 	"github.com/gofiber/fiber/v2"
@@ -17,6 +21,8 @@ import (
 //	Client.New(adapter.AdaptFiber(c)).Success(data).OkJSON()
 type fiberAdapter struct {
 	ctx *fiber.Ctx
+
+	streamCtx context.Context // Context of the WriterSender stream, canceled when it fails or ends
 }
 
 // Adapt converts fiber.Ctx into an Adapter.
@@ -28,7 +34,49 @@ func AdaptFiber(c *fiber.Ctx) adapter.Adapter {
 	return &fiberAdapter{ctx: c}
 }
 
-// Header returns the response headers map.
+// SetHeader sets a response header, replacing any existing values.
+func (f *fiberAdapter) SetHeader(key, value string) {
+	f.ctx.Response().Header.Set(key, value)
+}
+
+// AddHeader appends a value to a response header.
+func (f *fiberAdapter) AddHeader(key, value string) {
+	f.ctx.Response().Header.Add(key, value)
+}
+
+// DelHeader removes a response header.
+func (f *fiberAdapter) DelHeader(key string) {
+	f.ctx.Response().Header.Del(key)
+}
+
+// GetHeader returns the first value of a response header.
+func (f *fiberAdapter) GetHeader(key string) string {
+	if values := f.HeaderValues(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// HeaderValues returns all values of a response header.
+//
+// Set-Cookie values are read from the cookies, since fasthttp stores them apart from other headers.
+func (f *fiberAdapter) HeaderValues(key string) []string {
+	var values []string
+	if http.CanonicalHeaderKey(key) == "Set-Cookie" {
+		f.ctx.Response().Header.VisitAllCookie(func(_, value []byte) {
+			values = append(values, string(value))
+		})
+		return values
+	}
+	for _, value := range f.ctx.Response().Header.PeekAll(key) {
+		values = append(values, string(value))
+	}
+	return values
+}
+
+// Header returns a copy of the response headers, so changes to it are not sent.
+//
+// Deprecated: use SetHeader, AddHeader, DelHeader, GetHeader and HeaderValues.
 func (f *fiberAdapter) Header() http.Header {
 	header := make(http.Header)
 	f.ctx.Response().Header.VisitAll(func(key, value []byte) {
@@ -48,6 +96,48 @@ func (g *fiberAdapter) SetStatus(statusCode int) {
 	g.ctx.Status(statusCode)
 }
 
+// Method returns the request method.
+func (f *fiberAdapter) Method() string {
+	return strings.Clone(f.ctx.Method())
+}
+
+// Path returns the request URL path.
+func (f *fiberAdapter) Path() string {
+	return strings.Clone(f.ctx.Path())
+}
+
+// Query returns the parsed request query values.
+func (f *fiberAdapter) Query() url.Values {
+	values := make(url.Values)
+	f.ctx.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		values.Add(string(key), string(value))
+	})
+	return values
+}
+
+// RequestHeader returns the first value of the named request header.
+//
+// The value is copied because fiber reuses request buffers.
+func (f *fiberAdapter) RequestHeader(key string) string {
+	return strings.Clone(f.ctx.Get(key))
+}
+
+// RemoteAddr returns the client network address.
+func (f *fiberAdapter) RemoteAddr() string {
+	return f.ctx.Context().RemoteAddr().String()
+}
+
+// Context returns the user context of the request.
+// Inside a WriterSender stream, it returns a context derived from it instead:
+// fasthttp does not report client disconnects, so that context is canceled
+// when a write or flush fails, or once the stream ends.
+func (f *fiberAdapter) Context() context.Context {
+	if f.streamCtx != nil {
+		return f.streamCtx
+	}
+	return f.ctx.UserContext()
+}
+
 // JsonSender writes JSON response with given status.
 //
 // Please use reply to handle this sender.
@@ -90,6 +180,19 @@ func (f *fiberAdapter) BinarySender(statusCode int, data []byte) error {
 	return f.ctx.Send(data)
 }
 
+// BytesSender writes raw bytes with given Content-Type.
+//
+// Please use reply to handle this sender.
+//
+// Example:
+//
+//	f.BytesSender(200, "application/json", []byte(`{"msg":"ok"}`)) // -> {"msg":"ok"}
+func (f *fiberAdapter) BytesSender(statusCode int, contentType string, data []byte) error {
+	f.ctx.Status(statusCode)
+	f.ctx.Set("Content-Type", contentType)
+	return f.ctx.Send(data)
+}
+
 // TextSender writes plain text response.
 //
 // Please use reply to handle this sender.
@@ -99,6 +202,7 @@ func (f *fiberAdapter) BinarySender(statusCode int, data []byte) error {
 //	f.TextSender(200, "Hello!") // -> Hello!
 func (f *fiberAdapter) TextSender(statusCode int, text string) error {
 	f.ctx.Status(statusCode)
+	f.ctx.Set("Content-Type", "text/plain; charset=utf-8")
 	return f.ctx.SendString(text)
 }
 
@@ -125,13 +229,75 @@ func (f *fiberAdapter) HtmlSender(statusCode int, html string) error {
 func (f *fiberAdapter) StreamSender(statusCode int, contentType string, reader io.Reader) error {
 	f.ctx.Status(statusCode)
 	f.ctx.Set("Content-Type", contentType)
+
+	// fasthttp sends chunked unless given the size, and closes the reader when done
+	size := -1
+	if cl := f.ctx.Response().Header.Peek("Content-Length"); len(cl) > 0 {
+		if n, err := strconv.Atoi(string(cl)); err == nil {
+			size = n
+		}
+	}
+	f.ctx.Context().SetBodyStream(reader, size)
+	return nil
+}
+
+// WriterSender writes status and Content-Type, then lets write produce the body.
+// write runs after the handler returns, once fasthttp starts sending the body,
+// so its error can only stop the stream. A failed write or flush, e.g. after the client
+// disconnected, cancels Context so producers watching it stop.
+// Read Context inside write to get the stream context.
+//
+// Please use reply to handle this sender.
+//
+// Example:
+//
+//	f.WriterSender(200, "application/x-ndjson", func(w adapter.StreamWriter) error {
+//		io.WriteString(w, "{}\n")
+//		return w.Flush()
+//	})
+func (f *fiberAdapter) WriterSender(statusCode int, contentType string, write func(w adapter.StreamWriter) error) error {
+	f.ctx.Status(statusCode)
+	f.ctx.Set("Content-Type", contentType)
+	ctx, cancel := context.WithCancel(f.ctx.UserContext())
+	f.streamCtx = ctx
 	f.ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
-		io.Copy(w, reader)
-		w.Flush()
+		defer cancel()
+		if write(streamWriter{Writer: w, cancel: cancel}) == nil {
+			w.Flush()
+		}
 	})
 	return nil
 }
 
+// streamWriter implements adapter.StreamWriter over the fasthttp body stream.
+// The first failed write or flush cancels the adapter context.
+type streamWriter struct {
+	*bufio.Writer
+	cancel context.CancelFunc
+}
+
+// Write writes p to the body stream.
+func (s streamWriter) Write(p []byte) (int, error) {
+	n, err := s.Writer.Write(p)
+	if err != nil {
+		s.cancel()
+	}
+	return n, err
+}
+
+// Flush pushes buffered bytes to the client.
+func (s streamWriter) Flush() error {
+	err := s.Writer.Flush()
+	if err != nil {
+		s.cancel()
+	}
+	return err
+}
+
+// SetTrailer is a no-op: fasthttp writes headers concurrently with body stream writers,
+// so trailers can not be set safely from inside the stream.
+func (s streamWriter) SetTrailer(key, value string) {}
+
 // RedirectSender sends a redirect response.
 //
 // Please use reply to handle this sender.
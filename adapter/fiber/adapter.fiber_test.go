@@ -0,0 +1,19 @@
+package adapter
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/chesta132/goreply/adapter"
+	"github.com/chesta132/goreply/adapter/adaptertest"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestConformance runs the adapter conformance suite against the fiber adapter.
+func TestConformance(t *testing.T) {
+	adaptertest.Run(t, func(req *http.Request, handle func(adapter.Adapter)) (*http.Response, error) {
+		app := fiber.New()
+		app.All("/*", func(c *fiber.Ctx) error { handle(AdaptFiber(c)); return nil })
+		return app.Test(req, -1)
+	})
+}
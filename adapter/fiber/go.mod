@@ -19,3 +19,6 @@ require (
 	github.com/valyala/tcplisten v1.0.0 // indirect
 	golang.org/x/sys v0.28.0 // indirect
 )
+
+// Build against the goreply module of this repository.
+replace github.com/chesta132/goreply => ../..
@@ -0,0 +1,33 @@
+package adapter
+
+import (
+	"errors"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler returns a fiber.ErrorHandler that renders errors as goreply error replies.
+// *fiber.Error keeps its status code, ErrorPayload is rendered as is,
+// and other errors are mapped by the errors registered on the client.
+//
+// Example:
+//
+//	app := fiber.New(fiber.Config{
+//		ErrorHandler: adapter.ErrorHandler(Client),
+//	})
+func ErrorHandler(client *reply.Client) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		rp := client.Use(AdaptFiber(c))
+		if rp.Sent() {
+			return nil
+		}
+
+		var fe *fiber.Error
+		if _, isPayload := reply.AsErrorPayload(err); !isPayload && errors.As(err, &fe) {
+			err = reply.StatusError(fe.Code, fe.Message)
+		}
+
+		return rp.Err(err).Fail()
+	}
+}
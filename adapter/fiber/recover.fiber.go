@@ -0,0 +1,29 @@
+package adapter
+
+import (
+	"errors"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Recover returns a middleware that recovers panics and replies with a goreply error envelope.
+// See reply.Reply.Recover.
+//
+// Example:
+//
+//	app := fiber.New()
+//	app.Use(adapter.Recover(Client))
+func Recover(client *reply.Client) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = client.Use(AdaptFiber(c)).Recover(v)
+				if errors.Is(err, reply.ErrAlreadySent) {
+					err = nil
+				}
+			}
+		}()
+		return c.Next()
+	}
+}
@@ -1,8 +1,13 @@
 package adapter
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/chesta132/goreply/adapter"
 	"github.com/gin-gonic/gin"
@@ -26,7 +31,34 @@ func AdaptGin(c *gin.Context) adapter.Adapter {
 	return &ginAdapter{ctx: c}
 }
 
+// SetHeader sets a response header, replacing any existing values.
+func (g *ginAdapter) SetHeader(key, value string) {
+	g.ctx.Writer.Header().Set(key, value)
+}
+
+// AddHeader appends a value to a response header.
+func (g *ginAdapter) AddHeader(key, value string) {
+	g.ctx.Writer.Header().Add(key, value)
+}
+
+// DelHeader removes a response header.
+func (g *ginAdapter) DelHeader(key string) {
+	g.ctx.Writer.Header().Del(key)
+}
+
+// GetHeader returns the first value of a response header.
+func (g *ginAdapter) GetHeader(key string) string {
+	return g.ctx.Writer.Header().Get(key)
+}
+
+// HeaderValues returns all values of a response header.
+func (g *ginAdapter) HeaderValues(key string) []string {
+	return g.ctx.Writer.Header().Values(key)
+}
+
 // Header returns the response headers map.
+//
+// Deprecated: use SetHeader, AddHeader, DelHeader, GetHeader and HeaderValues.
 func (g *ginAdapter) Header() http.Header {
 	return g.ctx.Writer.Header()
 }
@@ -41,6 +73,36 @@ func (g *ginAdapter) SetStatus(statusCode int) {
 	g.ctx.Status(statusCode)
 }
 
+// Method returns the request method.
+func (g *ginAdapter) Method() string {
+	return g.ctx.Request.Method
+}
+
+// Path returns the request URL path.
+func (g *ginAdapter) Path() string {
+	return g.ctx.Request.URL.Path
+}
+
+// Query returns the parsed request query values.
+func (g *ginAdapter) Query() url.Values {
+	return g.ctx.Request.URL.Query()
+}
+
+// RequestHeader returns the first value of the named request header.
+func (g *ginAdapter) RequestHeader(key string) string {
+	return g.ctx.GetHeader(key)
+}
+
+// RemoteAddr returns the client network address.
+func (g *ginAdapter) RemoteAddr() string {
+	return g.ctx.Request.RemoteAddr
+}
+
+// Context returns the request context.
+func (g *ginAdapter) Context() context.Context {
+	return g.ctx.Request.Context()
+}
+
 // JsonSender writes JSON response with given status.
 //
 // Please use reply to handle this sender.
@@ -49,7 +111,12 @@ func (g *ginAdapter) SetStatus(statusCode int) {
 //
 //	g.JsonSender(200, map[string]any{"msg": "ok"}) // -> {"msg":"ok"}
 func (g *ginAdapter) JsonSender(statusCode int, data interface{}) error {
-	g.ctx.JSON(statusCode, data)
+	// marshal first, gin swallows render errors
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	g.ctx.Data(statusCode, "application/json; charset=utf-8", body)
 	return nil
 }
 
@@ -61,7 +128,12 @@ func (g *ginAdapter) JsonSender(statusCode int, data interface{}) error {
 //
 //	g.XmlSender(200, User{ID: 1}) // -> <User><ID>1</ID></User>
 func (g *ginAdapter) XmlSender(statusCode int, data interface{}) error {
-	g.ctx.XML(statusCode, data)
+	// marshal first, gin swallows render errors
+	body, err := xml.Marshal(data)
+	if err != nil {
+		return err
+	}
+	g.ctx.Data(statusCode, "application/xml; charset=utf-8", body)
 	return nil
 }
 
@@ -77,6 +149,18 @@ func (g *ginAdapter) BinarySender(statusCode int, data []byte) error {
 	return nil
 }
 
+// BytesSender writes raw bytes with given Content-Type.
+//
+// Please use reply to handle this sender.
+//
+// Example:
+//
+//	g.BytesSender(200, "application/json", []byte(`{"msg":"ok"}`)) // -> {"msg":"ok"}
+func (g *ginAdapter) BytesSender(statusCode int, contentType string, data []byte) error {
+	g.ctx.Data(statusCode, contentType, data)
+	return nil
+}
+
 // TextSender writes plain text response.
 //
 // Please use reply to handle this sender.
@@ -109,12 +193,57 @@ func (g *ginAdapter) HtmlSender(statusCode int, html string) error {
 //
 //	g.StreamSender(200, "video/mp4", fileReader) // streams MP4
 func (g *ginAdapter) StreamSender(statusCode int, contentType string, reader io.Reader) error {
+	if c, ok := reader.(io.Closer); ok {
+		defer c.Close()
+	}
 	g.ctx.Status(statusCode)
 	g.ctx.Header("Content-Type", contentType)
 	_, err := io.Copy(g.ctx.Writer, reader)
 	return err
 }
 
+// WriterSender writes status and Content-Type, then lets write produce the body.
+//
+// Please use reply to handle this sender.
+//
+// Example:
+//
+//	g.WriterSender(200, "application/x-ndjson", func(w adapter.StreamWriter) error {
+//		io.WriteString(w, "{}\n")
+//		return w.Flush()
+//	})
+func (g *ginAdapter) WriterSender(statusCode int, contentType string, write func(w adapter.StreamWriter) error) error {
+	g.ctx.Status(statusCode)
+	g.ctx.Header("Content-Type", contentType)
+	g.ctx.Writer.WriteHeaderNow()
+
+	// gin.ResponseWriter.Flush panics if the underlying writer can not flush
+	var rw http.ResponseWriter = g.ctx.Writer
+	if u, ok := rw.(interface{ Unwrap() http.ResponseWriter }); ok {
+		rw = u.Unwrap()
+	}
+	return write(streamWriter{g.ctx.Writer, http.NewResponseController(rw)})
+}
+
+// streamWriter implements adapter.StreamWriter over a gin.ResponseWriter.
+type streamWriter struct {
+	gin.ResponseWriter
+	rc *http.ResponseController
+}
+
+// Flush sends buffered data to the client. It is a no-op if the response can not flush.
+func (s streamWriter) Flush() error {
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// SetTrailer sets an HTTP trailer sent after the body.
+func (s streamWriter) SetTrailer(key, value string) {
+	s.Header().Set(http.TrailerPrefix+key, value)
+}
+
 // RedirectSender sends a redirect response.
 //
 // Please use reply to handle this sender.
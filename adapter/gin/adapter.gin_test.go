@@ -0,0 +1,50 @@
+package adapter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/adapter"
+	"github.com/chesta132/goreply/adapter/adaptertest"
+	"github.com/gin-gonic/gin"
+)
+
+// TestConformance runs the adapter conformance suite against the gin adapter.
+func TestConformance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	adaptertest.Run(t, func(req *http.Request, handle func(adapter.Adapter)) (*http.Response, error) {
+		w := httptest.NewRecorder()
+		r := gin.New()
+		r.Any("/*path", func(c *gin.Context) { handle(AdaptGin(c)) })
+		r.ServeHTTP(w, req)
+		return w.Result(), nil
+	})
+}
+
+// plainWriter is an http.ResponseWriter that can not flush.
+type plainWriter struct{ *httptest.ResponseRecorder }
+
+func (p plainWriter) Header() http.Header         { return p.ResponseRecorder.Header() }
+func (p plainWriter) Write(b []byte) (int, error) { return p.ResponseRecorder.Write(b) }
+func (p plainWriter) WriteHeader(code int)        { p.ResponseRecorder.WriteHeader(code) }
+
+// TestStreamWriterFlushUnsupported checks that flushing a writer that can not flush is a no-op.
+func TestStreamWriterFlushUnsupported(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(plainWriter{rec})
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	err := AdaptGin(c).WriterSender(http.StatusOK, "text/plain", func(w adapter.StreamWriter) error {
+		io.WriteString(w, "streamed")
+		return w.Flush()
+	})
+	if err != nil {
+		t.Fatalf("WriterSender: %v", err)
+	}
+	if got := rec.Body.String(); got != "streamed" {
+		t.Errorf("body = %q, want %q", got, "streamed")
+	}
+}
@@ -39,3 +39,6 @@ require (
 	golang.org/x/tools v0.34.0 // indirect
 	google.golang.org/protobuf v1.36.9 // indirect
 )
+
+// Build against the goreply module of this repository.
+replace github.com/chesta132/goreply => ../..
@@ -0,0 +1,65 @@
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler returns a gin middleware that renders the last error of c.Errors
+// as a goreply error reply, once the handlers chain returns without writing the response.
+// ErrorPayload is rendered as is, with its own Status if set. Otherwise error status codes
+// set by c.Status are kept, and other errors are mapped by the errors registered on the client.
+// Responses already written, e.g. by c.AbortWithError, are left as is since their headers are sent.
+// Errors while replying are added to c.Errors.
+//
+// Example:
+//
+//	r := gin.New()
+//	r.Use(adapter.ErrorHandler(Client))
+func ErrorHandler(client *reply.Client) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+
+		rp := client.Use(AdaptGin(c))
+		if rp.Sent() {
+			return
+		}
+
+		err := last.Err
+		payload, isPayload := reply.AsErrorPayload(err)
+
+		var code []int
+		status := c.Writer.Status()
+		switch {
+		case isPayload && payload.Status != 0:
+			// the payload status wins over the gin status
+		case status >= http.StatusBadRequest:
+			// error status set by c.Status before c.Error
+			if !isPayload {
+				err = reply.StatusError(status, clientMessage(status, err), reply.WithCause(err))
+			}
+			code = append(code, status)
+		case !isPayload && last.IsType(gin.ErrorTypeBind):
+			err = reply.StatusError(http.StatusBadRequest, err.Error(), reply.WithCause(err))
+		}
+
+		if err := rp.Err(err).Fail(code...); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
+
+// clientMessage returns the error message for client errors and hides it for server errors.
+func clientMessage(status int, err error) string {
+	if status >= http.StatusInternalServerError {
+		return ""
+	}
+	return err.Error()
+}
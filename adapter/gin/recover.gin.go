@@ -0,0 +1,30 @@
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/gin-gonic/gin"
+)
+
+// Recover returns a middleware that recovers panics and replies with a goreply error envelope.
+// See reply.Reply.Recover.
+//
+// Example:
+//
+//	r := gin.New()
+//	r.Use(adapter.Recover(Client))
+func Recover(client *reply.Client) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				client.Use(AdaptGin(c)).Recover(v)
+				c.Abort()
+			}
+		}()
+		c.Next()
+	}
+}
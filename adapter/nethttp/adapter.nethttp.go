@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/chesta132/goreply/adapter"
 )
@@ -29,7 +31,34 @@ func AdaptHttp(w http.ResponseWriter, r *http.Request) adapter.Adapter {
 	return &netHttpAdapter{w: w, r: r}
 }
 
+// SetHeader sets a response header, replacing any existing values.
+func (a *netHttpAdapter) SetHeader(key, value string) {
+	a.w.Header().Set(key, value)
+}
+
+// AddHeader appends a value to a response header.
+func (a *netHttpAdapter) AddHeader(key, value string) {
+	a.w.Header().Add(key, value)
+}
+
+// DelHeader removes a response header.
+func (a *netHttpAdapter) DelHeader(key string) {
+	a.w.Header().Del(key)
+}
+
+// GetHeader returns the first value of a response header.
+func (a *netHttpAdapter) GetHeader(key string) string {
+	return a.w.Header().Get(key)
+}
+
+// HeaderValues returns all values of a response header.
+func (a *netHttpAdapter) HeaderValues(key string) []string {
+	return a.w.Header().Values(key)
+}
+
 // Header returns the response headers map.
+//
+// Deprecated: use SetHeader, AddHeader, DelHeader, GetHeader and HeaderValues.
 func (a *netHttpAdapter) Header() http.Header {
 	return a.w.Header()
 }
@@ -44,6 +73,36 @@ func (a *netHttpAdapter) SetStatus(statusCode int) {
 	a.w.WriteHeader(statusCode)
 }
 
+// Method returns the request method.
+func (a *netHttpAdapter) Method() string {
+	return a.r.Method
+}
+
+// Path returns the request URL path.
+func (a *netHttpAdapter) Path() string {
+	return a.r.URL.Path
+}
+
+// Query returns the parsed request query values.
+func (a *netHttpAdapter) Query() url.Values {
+	return a.r.URL.Query()
+}
+
+// RequestHeader returns the first value of the named request header.
+func (a *netHttpAdapter) RequestHeader(key string) string {
+	return a.r.Header.Get(key)
+}
+
+// RemoteAddr returns the client network address.
+func (a *netHttpAdapter) RemoteAddr() string {
+	return a.r.RemoteAddr
+}
+
+// Context returns the request context.
+func (a *netHttpAdapter) Context() context.Context {
+	return a.r.Context()
+}
+
 // JsonSender writes JSON response with given status.
 //
 // Please use reply to handle this sender.
@@ -84,6 +143,20 @@ func (a *netHttpAdapter) BinarySender(statusCode int, data []byte) error {
 	return err
 }
 
+// BytesSender writes raw bytes with given Content-Type.
+//
+// Please use reply to handle this sender.
+//
+// Example:
+//
+//	a.BytesSender(200, "application/json", []byte(`{"msg":"ok"}`)) // -> {"msg":"ok"}
+func (a *netHttpAdapter) BytesSender(statusCode int, contentType string, data []byte) error {
+	a.w.Header().Set("Content-Type", contentType)
+	a.w.WriteHeader(statusCode)
+	_, err := a.w.Write(data)
+	return err
+}
+
 // TextSender writes plain text response.
 //
 // Please use reply to handle this sender.
@@ -120,12 +193,56 @@ func (a *netHttpAdapter) HtmlSender(statusCode int, html string) error {
 //
 //	a.StreamSender(200, "video/mp4", fileReader) // streams MP4
 func (a *netHttpAdapter) StreamSender(statusCode int, contentType string, reader io.Reader) error {
+	if c, ok := reader.(io.Closer); ok {
+		defer c.Close()
+	}
 	a.w.Header().Set("Content-Type", contentType)
 	a.w.WriteHeader(statusCode)
 	_, err := io.Copy(a.w, reader)
 	return err
 }
 
+// WriterSender writes status and Content-Type, then lets write produce the body.
+// Flush is a no-op if the ResponseWriter can not flush.
+//
+// Please use reply to handle this sender.
+//
+// Example:
+//
+//	a.WriterSender(200, "application/x-ndjson", func(w adapter.StreamWriter) error {
+//		io.WriteString(w, "{}\n")
+//		return w.Flush()
+//	})
+func (a *netHttpAdapter) WriterSender(statusCode int, contentType string, write func(w adapter.StreamWriter) error) error {
+	a.w.Header().Set("Content-Type", contentType)
+	a.w.WriteHeader(statusCode)
+	return write(&streamWriter{w: a.w, rc: http.NewResponseController(a.w)})
+}
+
+// streamWriter implements adapter.StreamWriter over an http.ResponseWriter.
+type streamWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// Write writes p to the response body.
+func (s *streamWriter) Write(p []byte) (int, error) {
+	return s.w.Write(p)
+}
+
+// Flush sends buffered data to the client. It is a no-op if the ResponseWriter can not flush.
+func (s *streamWriter) Flush() error {
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// SetTrailer sets an HTTP trailer sent after the body.
+func (s *streamWriter) SetTrailer(key, value string) {
+	s.w.Header().Set(http.TrailerPrefix+key, value)
+}
+
 // RedirectSender sends a redirect response.
 //
 // Please use reply to handle this sender.
@@ -152,7 +269,9 @@ func (a *netHttpAdapter) Get(key any) (any, bool) {
 	return value, value != nil
 }
 
-// Set sets value to request context.
+// Set sets value to the request context of this adapter.
+// The caller's *http.Request is not modified, so the value is not seen by other adapters;
+// use Middleware or reply.NewContext to share a reply instance downstream.
 //
 // Please use reply to handle this sender.
 //
@@ -0,0 +1,19 @@
+package adapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/adapter"
+	"github.com/chesta132/goreply/adapter/adaptertest"
+)
+
+// TestConformance runs the adapter conformance suite against the net/http adapter.
+func TestConformance(t *testing.T) {
+	adaptertest.Run(t, func(req *http.Request, handle func(adapter.Adapter)) (*http.Response, error) {
+		w := httptest.NewRecorder()
+		handle(AdaptHttp(w, req))
+		return w.Result(), nil
+	})
+}
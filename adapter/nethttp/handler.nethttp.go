@@ -0,0 +1,65 @@
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/chesta132/goreply/reply"
+)
+
+// HandlerFunc is a net/http handler that returns an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Handle converts h into an http.Handler that renders returned errors
+// as goreply error replies with the client, unless the response was already written.
+//
+// Example:
+//
+//	mux.Handle("/users/{id}", nethttpadapter.Handle(Client, func(w http.ResponseWriter, r *http.Request) error {
+//		user, err := findUser(r.PathValue("id"))
+//		if err != nil {
+//			return err // e.g. reply.NotFound("User not found")
+//		}
+//		return Client.New(nethttpadapter.AdaptHttp(w, r)).Success(user).OkJSON()
+//	}))
+func Handle(client *reply.Client, h HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tw := &trackingWriter{ResponseWriter: w}
+		rp, r := withReply(client, tw, r)
+		err := h(tw, r)
+		if err == nil || tw.written || rp.Sent() {
+			return
+		}
+		rp.Err(err).Fail()
+	})
+}
+
+// trackingWriter records whether the response has been written.
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+// WriteHeader writes the status code and marks the response as written.
+func (w *trackingWriter) WriteHeader(statusCode int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write writes the body and marks the response as written.
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush flushes buffered data to the client, if supported by the underlying writer.
+func (w *trackingWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.written = true
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *trackingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
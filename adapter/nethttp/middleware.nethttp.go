@@ -0,0 +1,40 @@
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/chesta132/goreply/reply"
+)
+
+// Middleware returns a middleware that creates the reply instance of each request
+// and stores it in the request context passed downstream, so Client.Use and
+// reply.FromContext return the same instance in later middlewares and handlers.
+//
+// The instance writes to the http.ResponseWriter given to this middleware.
+//
+// Example:
+//
+//	http.ListenAndServe(":8080", nethttpadapter.Middleware(Client)(auth(mux)))
+//
+//	// auth middleware
+//	Client.Use(nethttpadapter.AdaptHttp(w, r)).SetCookies(cookie).Debug("cookie refreshed")
+//	// handler
+//	Client.Use(nethttpadapter.AdaptHttp(w, r)).Success(data).OkJSON() // cookie and debug replied
+func Middleware(client *reply.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, r = withReply(client, w, r)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// withReply returns the reply instance of the request context, or creates one
+// and returns the request with a context carrying it.
+func withReply(client *reply.Client, w http.ResponseWriter, r *http.Request) (*reply.Reply, *http.Request) {
+	if rp, ok := reply.FromContext(r.Context()); ok {
+		return rp, r
+	}
+	rp := client.New(AdaptHttp(w, r))
+	return rp, r.WithContext(reply.NewContext(r.Context(), rp))
+}
@@ -0,0 +1,80 @@
+package adapter_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nethttpadapter "github.com/chesta132/goreply/adapter/nethttp"
+	"github.com/chesta132/goreply/reply"
+)
+
+// serveChain serves a GET / through a middleware setting a cookie and debug info,
+// then a handler replying with data. Returns the response and its decoded envelope.
+func serveChain(t *testing.T, client *reply.Client, wrap func(http.Handler) http.Handler) (*http.Response, reply.ReplyEnvelope) {
+	t.Helper()
+	middleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client.Use(nethttpadapter.AdaptHttp(w, r)).
+				SetCookies(http.Cookie{Name: "session", Value: "refreshed"}).
+				Debug("cookie refreshed")
+			next.ServeHTTP(w, r)
+		})
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client.Use(nethttpadapter.AdaptHttp(w, r)).Success("ok").OkJSON()
+	})
+
+	w := httptest.NewRecorder()
+	wrap(middleware(handler)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	res := w.Result()
+	var envelope reply.ReplyEnvelope
+	if err := json.NewDecoder(res.Body).Decode(&envelope); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	return res, envelope
+}
+
+func TestMiddlewareSharesReply(t *testing.T) {
+	tests := []struct {
+		name string
+		wrap func(client *reply.Client) func(http.Handler) http.Handler
+	}{
+		{"Middleware", nethttpadapter.Middleware},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(reply.Client{DebugMode: true})
+			res, envelope := serveChain(t, client, tt.wrap(client))
+
+			cookies := res.Cookies()
+			if len(cookies) != 1 || cookies[0].Name != "session" || cookies[0].Value != "refreshed" {
+				t.Errorf("cookies = %v, want session=refreshed", cookies)
+			}
+			if envelope.Data != "ok" {
+				t.Errorf("data = %v, want ok", envelope.Data)
+			}
+			if envelope.Meta.Debug == nil {
+				t.Errorf("meta.debug is empty, want the middleware debug info")
+			}
+		})
+	}
+}
+
+// TestUseKeepsRequest checks that Client.Use does not modify the request it is given.
+func TestUseKeepsRequest(t *testing.T) {
+	client := reply.NewClient(reply.Client{})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx := r.Context()
+
+	client.Use(nethttpadapter.AdaptHttp(httptest.NewRecorder(), r))
+	if r.Context() != ctx {
+		t.Errorf("request context was replaced")
+	}
+	if _, ok := reply.FromContext(r.Context()); ok {
+		t.Errorf("reply instance stored in the caller's request")
+	}
+}
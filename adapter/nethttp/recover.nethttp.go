@@ -0,0 +1,43 @@
+package adapter
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/chesta132/goreply/reply"
+)
+
+// Recover returns a middleware that recovers panics and replies with a goreply error envelope.
+// See reply.Reply.Recover. If the response was already partially written,
+// the panic is reported and the connection is aborted instead.
+//
+// Example:
+//
+//	http.ListenAndServe(":8080", nethttpadapter.Recover(Client)(mux))
+func Recover(client *reply.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tw := &trackingWriter{ResponseWriter: w}
+			rp, r := withReply(client, tw, r)
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+
+				if !tw.written || rp.Sent() {
+					rp.Recover(v)
+					return
+				}
+				if client.OnPanic != nil {
+					client.OnPanic(rp, v, debug.Stack())
+				}
+				panic(http.ErrAbortHandler)
+			}()
+			next.ServeHTTP(tw, r)
+		})
+	}
+}
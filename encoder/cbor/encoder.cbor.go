@@ -0,0 +1,37 @@
+package encoder
+
+import (
+	"io"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// CBOREncoder encodes payloads as CBOR (RFC 8949).
+// Field names follow cbor struct tags, then json struct tags.
+//
+// Example:
+//
+//	Client.RegisterEncoder(reply.FormatCBOR, encoder.CBOREncoder{})
+type CBOREncoder struct{}
+
+// ContentType returns "application/cbor".
+func (CBOREncoder) ContentType() string {
+	return "application/cbor"
+}
+
+// Encode writes v as CBOR to w.
+func (CBOREncoder) Encode(w io.Writer, v any) error {
+	return cbor.NewEncoder(w).Encode(v)
+}
+
+// Register registers CBOREncoder as reply.FormatCBOR on the client,
+// negotiated for application/cbor.
+//
+// Example:
+//
+//	encoder.Register(Client)
+//	rp.Success(data).OkCBOR()
+func Register(c *reply.Client) {
+	c.RegisterEncoder(reply.FormatCBOR, CBOREncoder{})
+}
@@ -0,0 +1,13 @@
+module github.com/chesta132/goreply/encoder/cbor
+
+go 1.25.0
+
+require (
+	github.com/chesta132/goreply v0.0.11
+	github.com/fxamacker/cbor/v2 v2.9.4
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
+
+// Build against the goreply module of this repository.
+replace github.com/chesta132/goreply => ../..
@@ -0,0 +1,39 @@
+package encoder
+
+import (
+	"io"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MsgPackEncoder encodes payloads as MessagePack.
+// Field names follow json struct tags, so envelopes match their JSON shape.
+//
+// Example:
+//
+//	Client.RegisterEncoder(reply.FormatMsgPack, encoder.MsgPackEncoder{}, "application/x-msgpack", "application/vnd.msgpack")
+type MsgPackEncoder struct{}
+
+// ContentType returns "application/msgpack".
+func (MsgPackEncoder) ContentType() string {
+	return "application/msgpack"
+}
+
+// Encode writes v as MessagePack to w.
+func (MsgPackEncoder) Encode(w io.Writer, v any) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	return enc.Encode(v)
+}
+
+// Register registers MsgPackEncoder as reply.FormatMsgPack on the client,
+// negotiated for application/msgpack, application/x-msgpack and application/vnd.msgpack.
+//
+// Example:
+//
+//	encoder.Register(Client)
+//	rp.Success(data).OkMsgPack()
+func Register(c *reply.Client) {
+	c.RegisterEncoder(reply.FormatMsgPack, MsgPackEncoder{}, "application/x-msgpack", "application/vnd.msgpack")
+}
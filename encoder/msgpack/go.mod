@@ -0,0 +1,13 @@
+module github.com/chesta132/goreply/encoder/msgpack
+
+go 1.25.0
+
+require (
+	github.com/chesta132/goreply v0.0.11
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+
+// Build against the goreply module of this repository.
+replace github.com/chesta132/goreply => ../..
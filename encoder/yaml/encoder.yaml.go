@@ -0,0 +1,37 @@
+package encoder
+
+import (
+	"io"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/goccy/go-yaml"
+)
+
+// YAMLEncoder encodes payloads as YAML.
+// Field names follow yaml struct tags, then json struct tags.
+//
+// Example:
+//
+//	Client.RegisterEncoder(reply.FormatYAML, encoder.YAMLEncoder{}, "application/x-yaml", "text/yaml")
+type YAMLEncoder struct{}
+
+// ContentType returns "application/yaml; charset=utf-8".
+func (YAMLEncoder) ContentType() string {
+	return "application/yaml; charset=utf-8"
+}
+
+// Encode writes v as YAML to w.
+func (YAMLEncoder) Encode(w io.Writer, v any) error {
+	return yaml.NewEncoder(w).Encode(v)
+}
+
+// Register registers YAMLEncoder as reply.FormatYAML on the client,
+// negotiated for application/yaml, application/x-yaml and text/yaml.
+//
+// Example:
+//
+//	encoder.Register(Client)
+//	rp.Success(data).OkYAML()
+func Register(c *reply.Client) {
+	c.RegisterEncoder(reply.FormatYAML, YAMLEncoder{}, "application/x-yaml", "text/yaml")
+}
@@ -0,0 +1,79 @@
+package encoder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+	"github.com/goccy/go-yaml"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// envelope mirrors the reply envelope with typed data.
+type envelope struct {
+	Meta struct {
+		Status string `json:"status"`
+	} `json:"meta"`
+	Data user `json:"data"`
+}
+
+func TestYAMLEncoder(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		send   func(rp *reply.Reply) error
+	}{
+		{"OkYAML", "", func(rp *reply.Reply) error { return rp.OkYAML() }},
+		{"Negotiated", "application/yaml", func(rp *reply.Reply) error { return rp.Ok() }},
+		{"NegotiatedAlias", "application/x-yaml", func(rp *reply.Reply) error { return rp.Ok() }},
+		{"NegotiatedTextAlias", "text/yaml", func(rp *reply.Reply) error { return rp.Ok() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(reply.Client{})
+			Register(client)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rp, rec := replytest.New(client, req)
+			if err := tt.send(rp.Success(user{ID: 42, Name: "Jane"})); err != nil {
+				t.Fatalf("send: %v", err)
+			}
+
+			rec.AssertStatus(t, http.StatusOK).AssertHeader(t, "Content-Type", "application/yaml; charset=utf-8")
+			var got envelope
+			if err := yaml.Unmarshal(rec.Body(), &got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got.Meta.Status != "SUCCESS" || got.Data != (user{ID: 42, Name: "Jane"}) {
+				t.Errorf("envelope = %+v", got)
+			}
+		})
+	}
+}
+
+func TestYAMLProblem(t *testing.T) {
+	client := reply.NewClient(reply.Client{ProblemDetails: true})
+	Register(client)
+	rp, rec := replytest.New(client, nil)
+	if err := rp.Error("NOT_FOUND", "User not found").FailYAML(http.StatusNotFound); err != nil {
+		t.Fatalf("FailYAML: %v", err)
+	}
+
+	rec.AssertStatus(t, http.StatusNotFound).AssertHeader(t, "Content-Type", "application/yaml; charset=utf-8")
+	var got reply.Problem
+	if err := yaml.Unmarshal(rec.Body(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Title != "Not Found" || got.Detail != "User not found" || got.Code != "NOT_FOUND" {
+		t.Errorf("problem = %+v", got)
+	}
+}
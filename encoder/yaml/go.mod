@@ -0,0 +1,11 @@
+module github.com/chesta132/goreply/encoder/yaml
+
+go 1.25.0
+
+require (
+	github.com/chesta132/goreply v0.0.11
+	github.com/goccy/go-yaml v1.19.2
+)
+
+// Build against the goreply module of this repository.
+replace github.com/chesta132/goreply => ../..
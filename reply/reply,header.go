@@ -28,7 +28,7 @@ func (r *Reply) SetCookies(cookies ...http.Cookie) *Reply {
 //
 //	rp.SetHeader("Content-Type", "application/json")
 func (r *Reply) SetHeader(key, value string) *Reply {
-	r.a.Header().Set(key, value)
+	r.a.SetHeader(key, value)
 	return r
 }
 
@@ -55,7 +55,7 @@ func (r *Reply) SetHeaders(headers map[string]string) *Reply {
 //
 //	rp.AddHeader("Set-Cookie", "session=abc123")
 func (r *Reply) AddHeader(key, value string) *Reply {
-	r.a.Header().Add(key, value)
+	r.a.AddHeader(key, value)
 	return r
 }
 
@@ -81,7 +81,7 @@ func (r *Reply) AddHeaders(headers map[string]string) *Reply {
 //
 //	rp.DeleteHeader("X-Powered-By")
 func (r *Reply) DeleteHeader(key string) *Reply {
-	r.a.Header().Del(key)
+	r.a.DelHeader(key)
 	return r
 }
 
@@ -104,7 +104,7 @@ func (r *Reply) DeleteHeaders(keys []string) *Reply {
 //
 //	contentType := rp.GetHeader("Content-Type")
 func (r *Reply) GetHeader(key string) string {
-	return r.a.Header().Get(key)
+	return r.a.GetHeader(key)
 }
 
 // GetHeaders retrieves multiple response header values by their keys.
@@ -117,7 +117,7 @@ func (r *Reply) GetHeader(key string) string {
 func (r *Reply) GetHeaders(keys []string) []string {
 	values := []string{}
 	for _, k := range keys {
-		v := r.a.Header().Get(k)
+		v := r.a.GetHeader(k)
 		values = append(values, v)
 	}
 	return values
@@ -1,6 +1,7 @@
 package reply
 
 import (
+	"context"
 	"os"
 
 	"github.com/chesta132/goreply/adapter"
@@ -49,7 +50,7 @@ func (c *Client) New(adapter adapter.Adapter) *Reply {
 	// set headers
 	if c.DefaultHeaders != nil {
 		for k, v := range c.DefaultHeaders {
-			rp.a.Header().Set(k, v)
+			rp.a.SetHeader(k, v)
 		}
 	}
 
@@ -57,6 +58,7 @@ func (c *Client) New(adapter adapter.Adapter) *Reply {
 }
 
 // Reuse instance or create new Reply instance.
+// The instance is looked up in the adapter values, then in the request context (see NewContext).
 //
 // Example:
 //
@@ -71,6 +73,9 @@ func (c *Client) Use(adapter adapter.Adapter) *Reply {
 			return rp
 		}
 	}
+	if rp, ok := FromContext(adapter.Context()); ok {
+		return rp
+	}
 
 	// create and set reply instance
 	rp = c.New(adapter)
@@ -78,3 +83,27 @@ func (c *Client) Use(adapter adapter.Adapter) *Reply {
 
 	return rp
 }
+
+// NewContext returns a copy of ctx carrying the reply instance.
+// Client.Use and FromContext return the instance from any adapter of a request with this context.
+//
+// Example:
+//
+//	rp := Client.New(nethttpadapter.AdaptHttp(w, r))
+//	next.ServeHTTP(w, r.WithContext(reply.NewContext(r.Context(), rp)))
+func NewContext(ctx context.Context, rp *Reply) context.Context {
+	return context.WithValue(ctx, clientKey, rp)
+}
+
+// FromContext returns the reply instance stored in ctx by NewContext.
+//
+// Example:
+//
+//	rp, ok := reply.FromContext(r.Context())
+func FromContext(ctx context.Context) (*Reply, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	rp, ok := ctx.Value(clientKey).(*Reply)
+	return rp, ok && rp != nil
+}
@@ -0,0 +1,254 @@
+package reply
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/chesta132/goreply/adapter"
+)
+
+// defaultCompressMin is the smallest body compressed when Client.CompressMin is not set.
+const defaultCompressMin = 1024
+
+// namedCompressor pairs a compressor with its content coding.
+type namedCompressor struct {
+	encoding   string
+	compressor Compressor
+}
+
+// defaultCompressors are offered after registered compressors.
+var defaultCompressors = []namedCompressor{
+	{"gzip", func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }},
+	{"deflate", func(w io.Writer) io.WriteCloser {
+		fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+		return fw
+	}},
+}
+
+// incompressibleTypes are media types already compressed, sent as is.
+var incompressibleTypes = map[string]bool{
+	"application/gzip":             true,
+	"application/x-gzip":           true,
+	"application/zip":              true,
+	"application/zstd":             true,
+	"application/x-brotli":         true,
+	"application/x-7z-compressed":  true,
+	"application/x-rar-compressed": true,
+	"application/vnd.rar":          true,
+	"application/x-bzip2":          true,
+	"application/x-xz":             true,
+	"font/woff":                    true,
+	"font/woff2":                   true,
+}
+
+// RegisterCompressor sets the compressor of a content coding, e.g. "br" or "zstd".
+// Registered codings are preferred over gzip and deflate when the client accepts them equally.
+// Registering "gzip" or "deflate" replaces the built-in compressor.
+//
+// Example:
+//
+//	Client.RegisterCompressor("br", func(w io.Writer) io.WriteCloser {
+//		return brotli.NewWriter(w)
+//	})
+func (c *Client) RegisterCompressor(encoding string, compressor Compressor) {
+	encoding = strings.ToLower(encoding)
+	for i, nc := range c.compressors {
+		if nc.encoding == encoding {
+			c.compressors[i].compressor = compressor
+			return
+		}
+	}
+	c.compressors = append(c.compressors, namedCompressor{encoding, compressor})
+}
+
+// compressorsInOrder lists registered compressors, then the built-in ones not replaced.
+func (c *Client) compressorsInOrder() []namedCompressor {
+	all := append([]namedCompressor(nil), c.compressors...)
+	for _, def := range defaultCompressors {
+		replaced := false
+		for _, nc := range c.compressors {
+			replaced = replaced || nc.encoding == def.encoding
+		}
+		if !replaced {
+			all = append(all, def)
+		}
+	}
+	return all
+}
+
+// compressibleType reports whether a content type is worth compressing.
+// Images, audio, video and archives are already compressed, SVG is the exception.
+func compressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	if mediaType == "" || mediaType == "image/svg+xml" {
+		return true
+	}
+	typ, _, _ := strings.Cut(mediaType, "/")
+	if typ == "image" || typ == "audio" || typ == "video" {
+		return false
+	}
+	return !incompressibleTypes[mediaType]
+}
+
+// acceptEncoding returns the q-value Accept-Encoding grants to a coding.
+// A coding not listed falls back to "*", and is not acceptable otherwise.
+func acceptEncoding(header, encoding string) float64 {
+	wildcard := 0.0
+	for part := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if key, value, ok := strings.Cut(params, "="); ok && strings.TrimSpace(strings.ToLower(key)) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		switch name {
+		case encoding:
+			return q
+		case "*":
+			wildcard = q
+		}
+	}
+	return wildcard
+}
+
+// compression picks the compressor for a body from Accept-Encoding.
+// size is the body length, or -1 for streams of unknown length.
+// Sets Vary: Accept-Encoding whenever the representation depends on it,
+// and weakens a strong ETag of compressed bodies since their bytes differ from the identity body.
+func (r *Reply) compression(contentType string, size int) (string, Compressor, bool) {
+	if !r.c.Compression || !compressibleType(contentType) {
+		return "", nil, false
+	}
+	minSize := r.c.CompressMin
+	if minSize <= 0 {
+		minSize = defaultCompressMin
+	}
+	if size >= 0 && size < minSize {
+		return "", nil, false
+	}
+	r.a.AddHeader("Vary", "Accept-Encoding")
+
+	header := r.a.RequestHeader("Accept-Encoding")
+	var best namedCompressor
+	bestQ := 0.0
+	for _, nc := range r.c.compressorsInOrder() {
+		if q := acceptEncoding(header, nc.encoding); q > bestQ {
+			best, bestQ = nc, q
+		}
+	}
+	if bestQ == 0 {
+		return "", nil, false
+	}
+
+	r.a.SetHeader("Content-Encoding", best.encoding)
+	r.a.DelHeader("Content-Length")
+	if etag := r.a.GetHeader("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		r.a.SetHeader("ETag", "W/"+etag)
+	}
+	return best.encoding, best.compressor, true
+}
+
+// sendBody sends a body with the given sender, or compressed with BytesSender if negotiated.
+func (r *Reply) sendBody(code int, contentType string, body []byte, sender func() error) error {
+	_, compressor, ok := r.compression(contentType, len(body))
+	if !ok {
+		return sender()
+	}
+
+	var buf bytes.Buffer
+	cw := compressor(&buf)
+	if _, err := cw.Write(body); err != nil {
+		return err
+	}
+	if err := cw.Close(); err != nil {
+		return err
+	}
+	return r.a.BytesSender(code, contentType, buf.Bytes())
+}
+
+// compressWriter compresses a stream, flushing the compressor before the response.
+type compressWriter struct {
+	adapter.StreamWriter
+	cw io.WriteCloser
+}
+
+// Write compresses p into the response.
+func (c compressWriter) Write(p []byte) (int, error) {
+	return c.cw.Write(p)
+}
+
+// Flush pushes pending compressed data to the client.
+func (c compressWriter) Flush() error {
+	if f, ok := c.cw.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return err
+		}
+	}
+	return c.StreamWriter.Flush()
+}
+
+// writerSender sends a streamed body through WriterSender, compressed if negotiated.
+func (r *Reply) writerSender(code int, contentType string, write func(w adapter.StreamWriter) error) error {
+	_, compressor, ok := r.compression(contentType, -1)
+	if !ok {
+		return r.a.WriterSender(code, contentType, write)
+	}
+	return r.a.WriterSender(code, contentType, func(w adapter.StreamWriter) error {
+		cw := compressWriter{StreamWriter: w, cw: compressor(w)}
+		if err := write(cw); err != nil {
+			cw.cw.Close()
+			return err
+		}
+		if err := cw.cw.Close(); err != nil {
+			return err
+		}
+		return w.Flush()
+	})
+}
+
+// streamBody sends a reader through StreamSender, or compressed through WriterSender if negotiated.
+// Compressed streams are flushed after every read, so live streams are not held back.
+func (r *Reply) streamBody(code int, contentType string, reader io.Reader) error {
+	_, compressor, ok := r.compression(contentType, -1)
+	if !ok {
+		return r.a.StreamSender(code, contentType, reader)
+	}
+	return r.a.WriterSender(code, contentType, func(w adapter.StreamWriter) error {
+		defer closeContent(reader)
+		cw := compressWriter{StreamWriter: w, cw: compressor(w)}
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				if _, werr := cw.Write(buf[:n]); werr != nil {
+					return werr
+				}
+				if ferr := cw.Flush(); ferr != nil {
+					return ferr
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := cw.cw.Close(); err != nil {
+			return err
+		}
+		return w.Flush()
+	})
+}
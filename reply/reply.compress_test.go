@@ -0,0 +1,173 @@
+package reply_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+// reverseCompressor is a custom coding writing the body reversed, to tell it apart.
+func reverseCompressor(w io.Writer) io.WriteCloser {
+	return &reverseWriter{w: w}
+}
+
+type reverseWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func (r *reverseWriter) Write(p []byte) (int, error) {
+	r.buf = append(r.buf, p...)
+	return len(p), nil
+}
+
+func (r *reverseWriter) Close() error {
+	slices.Reverse(r.buf)
+	_, err := r.w.Write(r.buf)
+	return err
+}
+
+// decompress decodes a body of the given content coding.
+func decompress(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+	var r io.Reader
+	switch encoding {
+	case "":
+		return string(body)
+	case "gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("gzip: %v", err)
+		}
+		r = gr
+	case "deflate":
+		r = flate.NewReader(bytes.NewReader(body))
+	case "rev":
+		reversed := slices.Clone(body)
+		slices.Reverse(reversed)
+		return string(reversed)
+	default:
+		t.Fatalf("unknown coding %q", encoding)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("decompress %s: %v", encoding, err)
+	}
+	return string(out)
+}
+
+func TestReplyCompression(t *testing.T) {
+	large := strings.Repeat("goreply ", 200)
+
+	tests := []struct {
+		name           string
+		client         reply.Client
+		custom         bool // registers reverseCompressor as "rev"
+		acceptEncoding string
+		data           string
+		encoding       string
+		vary           bool
+	}{
+		{name: "Gzip", client: reply.Client{Compression: true}, acceptEncoding: "gzip", data: large, encoding: "gzip", vary: true},
+		{name: "Deflate", client: reply.Client{Compression: true}, acceptEncoding: "deflate", data: large, encoding: "deflate", vary: true},
+		{name: "HighestQuality", client: reply.Client{Compression: true}, acceptEncoding: "gzip;q=0.5, deflate", data: large, encoding: "deflate", vary: true},
+		{name: "ZeroQuality", client: reply.Client{Compression: true}, acceptEncoding: "gzip;q=0, deflate;q=0", data: large, vary: true},
+		{name: "WildcardExcluded", client: reply.Client{Compression: true}, acceptEncoding: "*, gzip;q=0", data: large, encoding: "deflate", vary: true},
+		{name: "Identity", client: reply.Client{Compression: true}, acceptEncoding: "identity", data: large, vary: true},
+		{name: "NoAcceptEncoding", client: reply.Client{Compression: true}, data: large, vary: true},
+		{name: "BelowCompressMin", client: reply.Client{Compression: true}, acceptEncoding: "gzip", data: "small"},
+		{name: "CompressMin", client: reply.Client{Compression: true, CompressMin: 10}, acceptEncoding: "gzip", data: "small enough", encoding: "gzip", vary: true},
+		{name: "Disabled", acceptEncoding: "gzip", data: large},
+		{name: "RegisteredPreferred", client: reply.Client{Compression: true}, custom: true, acceptEncoding: "gzip, rev", data: large, encoding: "rev", vary: true},
+		{name: "RegisteredNotAccepted", client: reply.Client{Compression: true}, custom: true, acceptEncoding: "gzip", data: large, encoding: "gzip", vary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(tt.client)
+			if tt.custom {
+				client.RegisterCompressor("rev", reverseCompressor)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rp, rec := replytest.New(client, req)
+			if err := rp.Success(tt.data).OkText(); err != nil {
+				t.Fatalf("OkText: %v", err)
+			}
+
+			rec.AssertStatus(t, http.StatusOK).
+				AssertHeader(t, "Content-Encoding", tt.encoding).
+				AssertHeader(t, "Content-Type", "text/plain; charset=utf-8")
+			if got := slices.Contains(rec.Header().Values("Vary"), "Accept-Encoding"); got != tt.vary {
+				t.Errorf("Vary = %q, want Accept-Encoding %v", rec.Header().Values("Vary"), tt.vary)
+			}
+			if got := decompress(t, tt.encoding, rec.Body()); got != tt.data {
+				t.Errorf("body = %.40q..., want %.40q...", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestReplyCompressionWeakensETag(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rp, rec := replytest.New(reply.NewClient(reply.Client{Compression: true}), req)
+	if err := rp.Success(strings.Repeat("goreply ", 200)).ETag("v1").OkJSON(); err != nil {
+		t.Fatalf("OkJSON: %v", err)
+	}
+
+	rec.AssertHeader(t, "Content-Encoding", "gzip").AssertHeader(t, "ETag", `W/"v1"`)
+}
+
+// archiveEncoder writes []byte data as an already compressed archive.
+type archiveEncoder struct{}
+
+func (archiveEncoder) ContentType() string { return "application/zip" }
+
+func (archiveEncoder) Encode(w io.Writer, v any) error {
+	_, err := w.Write(v.(*reply.ReplyEnvelope).Data.([]byte))
+	return err
+}
+
+func TestReplyCompressionSkipsCompressed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	client := reply.NewClient(reply.Client{Compression: true, CompressMin: 1})
+	client.RegisterEncoder("zip", archiveEncoder{})
+	rp, rec := replytest.New(client, req)
+	body := bytes.Repeat([]byte("PK"), 1024)
+	if err := rp.Success(body).OkAs("zip"); err != nil {
+		t.Fatalf("OkAs: %v", err)
+	}
+
+	rec.AssertHeader(t, "Content-Type", "application/zip").AssertHeader(t, "Content-Encoding", "")
+	if !bytes.Equal(rec.Body(), body) {
+		t.Error("body was changed")
+	}
+}
+
+func TestReplyCompressionStream(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rp, rec := replytest.New(reply.NewClient(reply.Client{Compression: true}), req)
+	if err := rp.Success([]streamItem{{ID: 1, Name: "Jane"}}).OkNDJSON(); err != nil {
+		t.Fatalf("OkNDJSON: %v", err)
+	}
+
+	// streams have no known size, so CompressMin does not apply
+	rec.AssertHeader(t, "Content-Encoding", "gzip")
+	if got, want := decompress(t, "gzip", rec.Body()), `{"id":1,"name":"Jane"}`+"\n"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,210 @@
+package reply
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errNoOverlap is returned by parseRange if no range overlaps the content.
+var errNoOverlap = errors.New("invalid range: failed to overlap")
+
+// httpRange is a byte range of a content, as requested by a Range header.
+type httpRange struct {
+	start, length int64
+}
+
+// contentRange returns the Content-Range header value of the range.
+func (ra httpRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", ra.start, ra.start+ra.length-1, size)
+}
+
+// mimeHeader returns the part header of the range in a multipart/byteranges body.
+func (ra httpRange) mimeHeader(contentType string, size int64) textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Range": {ra.contentRange(size)},
+		"Content-Type":  {contentType},
+	}
+}
+
+// parseRange parses a Range header value as per RFC 9110 against a content of size bytes.
+// Returns errNoOverlap if ranges are valid but none overlaps the content.
+func parseRange(s string, size int64) ([]httpRange, error) {
+	const b = "bytes="
+	if !strings.HasPrefix(s, b) {
+		return nil, errors.New("invalid range")
+	}
+
+	var ranges []httpRange
+	noOverlap := false
+	for ra := range strings.SplitSeq(s[len(b):], ",") {
+		ra = textproto.TrimString(ra)
+		if ra == "" {
+			continue
+		}
+		start, end, ok := strings.Cut(ra, "-")
+		if !ok {
+			return nil, errors.New("invalid range")
+		}
+		start, end = textproto.TrimString(start), textproto.TrimString(end)
+
+		var r httpRange
+		if start == "" {
+			// suffix range "-N": the last N bytes
+			if end == "" || end[0] == '-' {
+				return nil, errors.New("invalid range")
+			}
+			n, err := strconv.ParseInt(end, 10, 64)
+			if n < 0 || err != nil {
+				return nil, errors.New("invalid range")
+			}
+			if n == 0 {
+				noOverlap = true
+				continue
+			}
+			n = min(n, size)
+			r.start = size - n
+			r.length = n
+		} else {
+			i, err := strconv.ParseInt(start, 10, 64)
+			if err != nil || i < 0 {
+				return nil, errors.New("invalid range")
+			}
+			if i >= size {
+				noOverlap = true
+				continue
+			}
+			r.start = i
+			if end == "" {
+				// open range "N-": from N to the end
+				r.length = size - r.start
+			} else {
+				i, err := strconv.ParseInt(end, 10, 64)
+				if err != nil || r.start > i {
+					return nil, errors.New("invalid range")
+				}
+				r.length = min(i, size-1) - r.start + 1
+			}
+		}
+		ranges = append(ranges, r)
+	}
+
+	if noOverlap && len(ranges) == 0 {
+		return nil, errNoOverlap
+	}
+	return ranges, nil
+}
+
+// sumRangesSize returns the number of bytes covered by the ranges.
+func sumRangesSize(ranges []httpRange) (size int64) {
+	for _, ra := range ranges {
+		size += ra.length
+	}
+	return size
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+// rangesMIMESize returns the length of the multipart/byteranges body of the ranges.
+func rangesMIMESize(ranges []httpRange, contentType string, size int64) int64 {
+	var w countingWriter
+	mw := multipart.NewWriter(&w)
+	for _, ra := range ranges {
+		mw.CreatePart(ra.mimeHeader(contentType, size))
+	}
+	mw.Close()
+	return int64(w) + sumRangesSize(ranges)
+}
+
+// isZeroTime reports whether t is unknown, either zero or the Unix epoch.
+func isZeroTime(t time.Time) bool {
+	return t.IsZero() || t.Equal(time.Unix(0, 0))
+}
+
+// modifiedSince reports whether modtime is later than the HTTP date, at second precision.
+// Returns true if the date can not be parsed.
+func modifiedSince(modtime time.Time, date string) bool {
+	t, err := http.ParseTime(date)
+	if err != nil {
+		return true
+	}
+	return modtime.Truncate(time.Second).After(t)
+}
+
+// preconditionFailed reports whether If-Match or, without it, If-Unmodified-Since fails.
+func (r *Reply) preconditionFailed(etag string, modtime time.Time) bool {
+	if im := r.a.RequestHeader("If-Match"); im != "" {
+		return !etagMatch(im, etag, false)
+	}
+	ius := r.a.RequestHeader("If-Unmodified-Since")
+	if ius == "" || isZeroTime(modtime) {
+		return false
+	}
+	t, err := http.ParseTime(ius)
+	return err == nil && modtime.Truncate(time.Second).After(t)
+}
+
+// notModified reports whether If-None-Match or, without it, If-Modified-Since
+// allows a 304 Not Modified for GET and HEAD.
+func (r *Reply) notModified(etag string, modtime time.Time) bool {
+	method := r.a.Method()
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+	if inm := r.a.RequestHeader("If-None-Match"); inm != "" {
+		return etagMatch(inm, etag, true)
+	}
+	ims := r.a.RequestHeader("If-Modified-Since")
+	if ims == "" || isZeroTime(modtime) {
+		return false
+	}
+	return !modifiedSince(modtime, ims)
+}
+
+// rangeAllowed reports whether If-Range allows the Range header to be honored.
+// Entity tags must match strongly, dates exactly.
+func (r *Reply) rangeAllowed(etag string, modtime time.Time) bool {
+	ir := r.a.RequestHeader("If-Range")
+	if ir == "" {
+		return true
+	}
+	if strings.HasPrefix(ir, `"`) || strings.HasPrefix(ir, `W/"`) {
+		return etagMatch(ir, etag, false)
+	}
+	if isZeroTime(modtime) {
+		return false
+	}
+	t, err := http.ParseTime(ir)
+	return err == nil && modtime.Truncate(time.Second).Equal(t)
+}
+
+// contentReader closes its source once the adapter is done with the body.
+type contentReader struct {
+	io.Reader
+	close func() error
+}
+
+// Close releases the content.
+func (c contentReader) Close() error {
+	return c.close()
+}
+
+// closeContent closes content if it implements io.Closer.
+func closeContent(content io.Reader) error {
+	if c, ok := content.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package reply
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// csvFlushRows is the number of rows written between flushes.
+const csvFlushRows = 100
+
+// csvColumn is a column of a struct row, resolved from csv or json tags.
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// tabularRows unwraps data into a slice value of structs or maps.
+func tabularRows(data any) (reflect.Value, bool) {
+	v := reflect.ValueOf(data)
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) {
+		v = v.Elem()
+	}
+	if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+		return v, false
+	}
+
+	switch indirectType(v.Type().Elem()).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return v, true
+	default:
+		return v, false
+	}
+}
+
+// indirectType strips pointer types.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// indirectValue strips pointers and interfaces. Returns an invalid value for nil.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// structColumns lists the exported fields of a struct type as columns.
+// Names come from csv tags, then json tags, then field names. Fields tagged "-" are skipped.
+func structColumns(t reflect.Type) []csvColumn {
+	var columns []csvColumn
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() {
+			continue
+		}
+		tag, ok := f.Tag.Lookup("csv")
+		if !ok {
+			tag = f.Tag.Get("json")
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		// promoted fields are listed on their own
+		if f.Anonymous && name == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		columns = append(columns, csvColumn{name: name, index: f.Index})
+	}
+	return columns
+}
+
+// mapColumns lists the sorted keys of a map row.
+func mapColumns(row reflect.Value) []string {
+	keys := make([]string, 0, row.Len())
+	for _, k := range row.MapKeys() {
+		keys = append(keys, fmt.Sprint(k.Interface()))
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// formatCell renders a value as a CSV cell.
+// Text marshalers use their text form, composite values are rendered as JSON.
+func formatCell(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	if v.CanInterface() {
+		if tm, ok := v.Interface().(encoding.TextMarshaler); ok {
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return ""
+			}
+			text, err := tm.MarshalText()
+			if err != nil {
+				return ""
+			}
+			return string(text)
+		}
+	}
+
+	v = indirectValue(v)
+	if !v.IsValid() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		if (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.IsNil() {
+			return ""
+		}
+		if v.CanInterface() {
+			if b, err := json.Marshal(v.Interface()); err == nil {
+				return string(b)
+			}
+		}
+	}
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+	return ""
+}
+
+// structCell returns the cell of a struct row at the field index.
+// Fields behind nil embedded pointers are empty.
+func structCell(row reflect.Value, index []int) string {
+	field, err := row.FieldByIndexErr(index)
+	if err != nil {
+		return ""
+	}
+	return formatCell(field)
+}
+
+// mapCell returns the cell of a map row under key.
+func mapCell(row reflect.Value, key string) string {
+	keyType := row.Type().Key()
+	if keyType.Kind() != reflect.String {
+		for _, k := range row.MapKeys() {
+			if fmt.Sprint(k.Interface()) == key {
+				return formatCell(row.MapIndex(k))
+			}
+		}
+		return ""
+	}
+	return formatCell(row.MapIndex(reflect.ValueOf(key).Convert(keyType)))
+}
+
+// writeCSV writes rows as delimited records to w, flushing every csvFlushRows rows.
+// Columns default to the fields of the row struct type or the keys of the first map row.
+// The header of struct rows is written even without rows.
+func writeCSV(w io.Writer, rows reflect.Value, comma rune, opts CSVOptions) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	// resolve columns from the element type, or the first non-nil row of interface slices
+	var first reflect.Value
+	for i := range rows.Len() {
+		if first = indirectValue(rows.Index(i)); first.IsValid() {
+			break
+		}
+	}
+	rowType := indirectType(rows.Type().Elem())
+	if rowType.Kind() != reflect.Struct && first.IsValid() {
+		rowType = first.Type()
+	}
+
+	var fields map[string][]int
+	columns := opts.Columns
+	if rowType.Kind() == reflect.Struct {
+		fields = make(map[string][]int)
+		var names []string
+		for _, c := range structColumns(rowType) {
+			fields[c.name] = c.index
+			names = append(names, c.name)
+		}
+		if len(columns) == 0 {
+			columns = names
+		}
+	} else if first.Kind() == reflect.Map && len(columns) == 0 {
+		columns = mapColumns(first)
+	}
+
+	if !opts.NoHeader && len(columns) > 0 {
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(columns))
+	for i := range rows.Len() {
+		row := indirectValue(rows.Index(i))
+		for j, column := range columns {
+			switch {
+			case !row.IsValid():
+				record[j] = ""
+			case row.Kind() == reflect.Struct:
+				index := fields[column]
+				if row.Type() != rowType {
+					index = fieldIndex(row.Type(), column)
+				}
+				if index == nil {
+					record[j] = ""
+					continue
+				}
+				record[j] = structCell(row, index)
+			case row.Kind() == reflect.Map:
+				record[j] = mapCell(row, column)
+			default:
+				record[j] = ""
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+		if (i+1)%csvFlushRows == 0 {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// fieldIndex looks up the field index of a column in a struct type.
+func fieldIndex(t reflect.Type, column string) []int {
+	for _, c := range structColumns(t) {
+		if c.name == column {
+			return c.index
+		}
+	}
+	return nil
+}
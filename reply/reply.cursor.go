@@ -0,0 +1,95 @@
+package reply
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// encodeCursor encodes a keyset as an opaque base64url cursor of its JSON.
+// If Client.CursorSecret is set, the HMAC-SHA256 signature is appended after a dot.
+//
+// Example:
+//
+//	c.encodeCursor([]any{"2024-01-02T15:04:05Z", 42}) // -> WyIyMDI0LTAxLTAyVDE1OjA0OjA1WiIsNDJd
+func (c *Client) encodeCursor(key any) (string, error) {
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	cursor := base64.RawURLEncoding.EncodeToString(payload)
+	if len(c.CursorSecret) > 0 {
+		cursor += "." + base64.RawURLEncoding.EncodeToString(c.cursorSignature(payload))
+	}
+	return cursor, nil
+}
+
+// decodeCursor verifies a cursor made by encodeCursor and decodes its keyset into v.
+func (c *Client) decodeCursor(cursor string, v any) error {
+	encoded, signature, signed := strings.Cut(cursor, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+
+	if len(c.CursorSecret) > 0 {
+		if !signed {
+			return errors.New("cursor is not signed")
+		}
+		mac, err := base64.RawURLEncoding.DecodeString(signature)
+		if err != nil {
+			return err
+		}
+		if !hmac.Equal(mac, c.cursorSignature(payload)) {
+			return errors.New("cursor signature mismatch")
+		}
+	} else if signed {
+		return errors.New("cursor is signed without CursorSecret")
+	}
+
+	return json.Unmarshal(payload, v)
+}
+
+// cursorSignature returns the HMAC-SHA256 of a cursor payload with Client.CursorSecret.
+func (c *Client) cursorSignature(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.CursorSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// cursorParam returns the query parameter of keyset cursors.
+func (c *Client) cursorParam() string {
+	if c.CursorParam != "" {
+		return c.CursorParam
+	}
+	return "cursor"
+}
+
+// DecodeCursor reads the keyset cursor of the request made by PaginateKeyset and decodes it into K.
+// Returns false if the request has no cursor, i.e. the first page is requested.
+// Malformed, tampered or mistyped cursors return an "INVALID_CURSOR" error with status 400.
+//
+// Example:
+//
+//	key, ok, err := reply.DecodeCursor[UserKey](rp)
+//	if err != nil {
+//		return rp.Err(err).Fail() // 400 INVALID_CURSOR
+//	}
+//	users := fetchUsersAfter(key, ok, limit+1)
+func DecodeCursor[K any](r *Reply) (K, bool, error) {
+	var key K
+	cursor := r.a.Query().Get(r.c.cursorParam())
+	if cursor == "" {
+		return key, false, nil
+	}
+	if err := r.c.decodeCursor(cursor, &key); err != nil {
+		var zero K
+		return zero, false, NewError(http.StatusBadRequest, "INVALID_CURSOR", "Invalid pagination cursor", WithCause(err))
+	}
+	return key, true, nil
+}
@@ -0,0 +1,185 @@
+package reply_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+type userKey struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+// itemKey is the keyset of a streamItem.
+func itemKey(last any) any {
+	item := last.(streamItem)
+	return userKey{Name: item.Name, ID: item.ID}
+}
+
+// nextCursor paginates items by keyset and returns the next cursor.
+func nextCursor(t *testing.T, client *reply.Client) string {
+	t.Helper()
+	rp, rec := replytest.New(client, nil)
+	items := []streamItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	rp, err := rp.Success(items).PaginateKeyset(2, itemKey)
+	if err != nil {
+		t.Fatalf("PaginateKeyset: %v", err)
+	}
+	if err := rp.OkJSON(); err != nil {
+		t.Fatalf("OkJSON: %v", err)
+	}
+	keyset := rec.Envelope(t).Meta.Keyset
+	if keyset == nil || !keyset.HasNext || keyset.NextCursor == "" {
+		t.Fatalf("meta.keyset = %+v, want a next cursor", keyset)
+	}
+	return keyset.NextCursor
+}
+
+func TestDecodeCursor(t *testing.T) {
+	secret := []byte("s3cret")
+	signed := nextCursor(t, reply.NewClient(reply.Client{CursorSecret: secret}))
+	unsigned := nextCursor(t, reply.NewClient(reply.Client{}))
+	encoded, signature, _ := strings.Cut(signed, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"name":"z","id":9}`)) + "." + signature
+
+	tests := []struct {
+		name    string
+		secret  []byte
+		cursor  string
+		want    userKey
+		wantOK  bool
+		invalid bool
+	}{
+		{name: "FirstPage", secret: secret},
+		{name: "Signed", secret: secret, cursor: signed, want: userKey{Name: "b", ID: 2}, wantOK: true},
+		{name: "Unsigned", cursor: unsigned, want: userKey{Name: "b", ID: 2}, wantOK: true},
+		{name: "OtherSecret", secret: []byte("other"), cursor: signed, invalid: true},
+		{name: "MissingSignature", secret: secret, cursor: unsigned, invalid: true},
+		{name: "TamperedPayload", secret: secret, cursor: forged, invalid: true},
+		{name: "TamperedSignature", secret: secret, cursor: encoded + ".AAAA", invalid: true},
+		{name: "SignedWithoutSecret", cursor: signed, invalid: true},
+		{name: "Malformed", cursor: "%%%", invalid: true},
+		{name: "Mistyped", cursor: "WzEsMl0", invalid: true}, // [1,2]
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/users"
+			if tt.cursor != "" {
+				target += "?cursor=" + url.QueryEscape(tt.cursor)
+			}
+			rp, rec := replytest.New(reply.NewClient(reply.Client{CursorSecret: tt.secret}), httptest.NewRequest(http.MethodGet, target, nil))
+
+			key, ok, err := reply.DecodeCursor[userKey](rp)
+			if tt.invalid {
+				if !errors.Is(err, reply.NewError(http.StatusBadRequest, "INVALID_CURSOR", "")) {
+					t.Fatalf("err = %v, want INVALID_CURSOR", err)
+				}
+				rp.Err(err).Fail()
+				rec.AssertStatus(t, http.StatusBadRequest).AssertErrorCode(t, "INVALID_CURSOR")
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if key != tt.want || ok != tt.wantOK {
+				t.Errorf("DecodeCursor = %+v, %v, want %+v, %v", key, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPaginateKeyset(t *testing.T) {
+	items := []streamItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+
+	tests := []struct {
+		name     string
+		client   reply.Client
+		data     any
+		limit    int
+		key      func(last any) any
+		wantErr  bool
+		keyset   bool
+		hasNext  bool
+		link     string
+		dataSize int
+	}{
+		{name: "HasNext", data: items, limit: 2, key: itemKey, keyset: true, hasNext: true, dataSize: 2},
+		{name: "LastPage", data: items, limit: 3, key: itemKey, keyset: true, dataSize: 3},
+		{name: "NotASlice", data: items[0], limit: 2, key: itemKey},
+		{
+			name:    "EncodeError",
+			data:    items,
+			limit:   2,
+			key:     func(last any) any { return make(chan int) },
+			wantErr: true,
+		},
+		{
+			name:     "Links",
+			client:   reply.Client{PaginationLinks: true, CursorParam: "after"},
+			data:     items,
+			limit:    2,
+			key:      itemKey,
+			keyset:   true,
+			hasNext:  true,
+			link:     `</users?q=go>; rel="first", </users?after=eyJuYW1lIjoiYiIsImlkIjoyfQ&q=go>; rel="next"`,
+			dataSize: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users?q=go&after=old", nil)
+			rp, rec := replytest.New(reply.NewClient(tt.client), req)
+			rp, err := rp.Success(tt.data).PaginateKeyset(tt.limit, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err := rp.OkJSON(); err != nil {
+				t.Fatalf("OkJSON: %v", err)
+			}
+
+			rec.AssertHeader(t, "Link", tt.link)
+			keyset := rec.Envelope(t).Meta.Keyset
+			if (keyset != nil) != tt.keyset {
+				t.Fatalf("meta.keyset = %+v, want present %v", keyset, tt.keyset)
+			}
+			if keyset == nil {
+				return
+			}
+			if keyset.HasNext != tt.hasNext || (keyset.NextCursor != "") != tt.hasNext {
+				t.Errorf("meta.keyset = %+v, want hasNext %v", keyset, tt.hasNext)
+			}
+			var data []streamItem
+			rec.DecodeData(t, &data)
+			if len(data) != tt.dataSize {
+				t.Errorf("len(data) = %d, want %d", len(data), tt.dataSize)
+			}
+		})
+	}
+}
+
+func TestPaginateKeysetCSV(t *testing.T) {
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+	rp, err := rp.Success([]streamItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}).PaginateKeyset(1, itemKey)
+	if err != nil {
+		t.Fatalf("PaginateKeyset: %v", err)
+	}
+	if err := rp.OkCSV(); err != nil {
+		t.Fatalf("OkCSV: %v", err)
+	}
+
+	rec.AssertHeader(t, "X-Pagination-Has-Next", "true").
+		AssertHeader(t, "X-Pagination-Next-Cursor", "eyJuYW1lIjoiYSIsImlkIjoxfQ")
+	if got := string(rec.Body()); got != "id,name\n1,a\n" {
+		t.Errorf("body = %q", got)
+	}
+}
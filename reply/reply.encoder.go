@@ -0,0 +1,117 @@
+package reply
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"slices"
+)
+
+// formatEncoder pairs a registered encoder with the media types it is negotiated for.
+type formatEncoder struct {
+	encoder    Encoder
+	mediaTypes []string
+}
+
+// defaultEncoders are used for formats without a registered encoder.
+var defaultEncoders = map[Format]Encoder{
+	FormatJSON: JSONEncoder{},
+	FormatXML:  XMLEncoder{},
+}
+
+// JSONEncoder encodes payloads with encoding/json.
+//
+// Example:
+//
+//	Client.RegisterEncoder(reply.FormatJSON, reply.JSONEncoder{Indent: "  ", DisableHTMLEscape: true})
+type JSONEncoder struct {
+	Indent            string // Indentation of nested values. Default: "" (compact)
+	DisableHTMLEscape bool   // If true, <, > and & are not escaped in strings. Default: false
+}
+
+// ContentType returns "application/json; charset=utf-8".
+func (e JSONEncoder) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+// Encode writes v as JSON to w.
+func (e JSONEncoder) Encode(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(!e.DisableHTMLEscape)
+	if e.Indent != "" {
+		enc.SetIndent("", e.Indent)
+	}
+	return enc.Encode(v)
+}
+
+// XMLEncoder encodes payloads with encoding/xml.
+//
+// Example:
+//
+//	Client.RegisterEncoder(reply.FormatXML, reply.XMLEncoder{Indent: "  "})
+type XMLEncoder struct {
+	Indent string // Indentation of nested elements. Default: "" (compact)
+}
+
+// ContentType returns "application/xml; charset=utf-8".
+func (e XMLEncoder) ContentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+// Encode writes v as XML to w.
+func (e XMLEncoder) Encode(w io.Writer, v any) error {
+	enc := xml.NewEncoder(w)
+	if e.Indent != "" {
+		enc.Indent("", e.Indent)
+	}
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// RegisterEncoder sets the encoder of a format, replacing the built-in or previous one.
+// The format is negotiated for the encoder content type and the extra mediaTypes.
+// Formats other than JSON and XML are offered by Reply after the built-in ones, in registration order.
+//
+// Example:
+//
+//	Client.RegisterEncoder("msgpack", MsgPackEncoder{}, "application/x-msgpack")
+//	rp.Success(user).OkAs("msgpack")
+func (c *Client) RegisterEncoder(format Format, encoder Encoder, mediaTypes ...string) {
+	if c.encoders == nil {
+		c.encoders = make(map[Format]formatEncoder)
+	}
+	types := make([]string, 0, len(mediaTypes)+1)
+	if mediaType, _, err := mime.ParseMediaType(encoder.ContentType()); err == nil {
+		types = append(types, mediaType)
+	}
+	for _, mediaType := range mediaTypes {
+		if !slices.Contains(types, mediaType) {
+			types = append(types, mediaType)
+		}
+	}
+
+	if _, ok := c.encoders[format]; !ok {
+		c.formats = append(c.formats, format)
+	}
+	c.encoders[format] = formatEncoder{encoder: encoder, mediaTypes: types}
+}
+
+// encoder returns the encoder of a format, falling back to the built-in JSON and XML encoders.
+func (c *Client) encoder(format Format) (Encoder, bool) {
+	if fe, ok := c.encoders[format]; ok {
+		return fe.encoder, true
+	}
+	enc, ok := defaultEncoders[format]
+	return enc, ok
+}
+
+// mediaTypes returns the media types a format is negotiated for.
+func (c *Client) mediaTypes(format Format) []string {
+	if fe, ok := c.encoders[format]; ok {
+		return fe.mediaTypes
+	}
+	return formatMediaTypes[format]
+}
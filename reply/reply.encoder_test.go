@@ -0,0 +1,117 @@
+package reply_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+func TestRegisterEncoder(t *testing.T) {
+	tests := []struct {
+		name        string
+		register    func(c *reply.Client)
+		send        func(rp *reply.Reply) error
+		contentType string
+		contains    []string
+	}{
+		{
+			name:        "DefaultJSON",
+			send:        func(rp *reply.Reply) error { return rp.Success("<b>").OkJSON() },
+			contentType: "application/json; charset=utf-8",
+			contains:    []string{`"data":"\u003cb\u003e"`},
+		},
+		{
+			name: "JSONIndent",
+			register: func(c *reply.Client) {
+				c.RegisterEncoder(reply.FormatJSON, reply.JSONEncoder{Indent: "  "})
+			},
+			send:        func(rp *reply.Reply) error { return rp.Success("hi").OkJSON() },
+			contentType: "application/json; charset=utf-8",
+			contains:    []string{"{\n  \"meta\": {\n    \"status\": \"SUCCESS\"", "\n  \"data\": \"hi\"\n}"},
+		},
+		{
+			name: "JSONDisableHTMLEscape",
+			register: func(c *reply.Client) {
+				c.RegisterEncoder(reply.FormatJSON, reply.JSONEncoder{DisableHTMLEscape: true})
+			},
+			send:        func(rp *reply.Reply) error { return rp.Success("<b>").OkJSON() },
+			contentType: "application/json; charset=utf-8",
+			contains:    []string{`"data":"<b>"`},
+		},
+		{
+			name: "XMLIndent",
+			register: func(c *reply.Client) {
+				c.RegisterEncoder(reply.FormatXML, reply.XMLEncoder{Indent: "  "})
+			},
+			send:        func(rp *reply.Reply) error { return rp.Success("hi").OkXML() },
+			contentType: "application/xml; charset=utf-8",
+			contains:    []string{"<ReplyEnvelope>\n  <meta>\n    <status>SUCCESS</status>", "\n  <data>hi</data>\n</ReplyEnvelope>"},
+		},
+		{
+			name: "CustomFormat",
+			register: func(c *reply.Client) {
+				c.RegisterEncoder("custom", textEncoder{})
+			},
+			send:        func(rp *reply.Reply) error { return rp.Success("hi").OkAs("custom") },
+			contentType: "text/x-custom",
+			contains:    []string{"custom"},
+		},
+		{
+			name: "ReplacedJSON",
+			register: func(c *reply.Client) {
+				c.RegisterEncoder(reply.FormatJSON, textEncoder{})
+			},
+			send:        func(rp *reply.Reply) error { return rp.Success("hi").OkJSON() },
+			contentType: "text/x-custom",
+			contains:    []string{"custom"},
+		},
+		{
+			name: "CreatedAs",
+			register: func(c *reply.Client) {
+				c.RegisterEncoder("custom", textEncoder{})
+			},
+			send:        func(rp *reply.Reply) error { return rp.Success("hi").CreatedAs("custom") },
+			contentType: "text/x-custom",
+			contains:    []string{"custom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(reply.Client{})
+			if tt.register != nil {
+				tt.register(client)
+			}
+			rp, rec := replytest.New(client, nil)
+			if err := tt.send(rp); err != nil {
+				t.Fatalf("send: %v", err)
+			}
+
+			rec.AssertHeader(t, "Content-Type", tt.contentType)
+			for _, want := range tt.contains {
+				if !strings.Contains(string(rec.Body()), want) {
+					t.Errorf("body does not contain %q\nbody: %s", want, rec.Body())
+				}
+			}
+		})
+	}
+}
+
+func TestReplyAsEncoderNotFound(t *testing.T) {
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+	err := rp.Success("hi").ReplyAs("custom", http.StatusOK)
+
+	if !errors.Is(err, reply.ErrEncoderNotFound) {
+		t.Errorf("err = %v, want ErrEncoderNotFound", err)
+	}
+	if len(rec.Body()) != 0 {
+		t.Errorf("body = %s, want nothing sent", rec.Body())
+	}
+	if err := rp.OkJSON(); err != nil {
+		t.Errorf("OkJSON after a missing encoder: %v", err)
+	}
+}
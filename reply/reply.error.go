@@ -1,8 +1,171 @@
 package reply
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
 
 var (
-	ErrAlreadySent    = errors.New("reply: can not send more data, response already sent")
-	ErrPresetNotFound = errors.New("reply: preset not found")
+	ErrAlreadySent      = errors.New("reply: can not send more data, response already sent")
+	ErrPresetNotFound   = errors.New("reply: preset not found")
+	ErrEncoderNotFound  = errors.New("reply: encoder not found")
+	ErrNotTabular       = errors.New("reply: data is not a slice of structs or maps")
+	ErrNotIterable      = errors.New("reply: data is not an iterator, channel or slice")
+	ErrNoTemplates      = errors.New("reply: templates not loaded")
+	ErrTemplateNotFound = errors.New("reply: template not found")
 )
+
+// Error returns the error code and message, followed by the cause (if any).
+func (e ErrorPayload) Error() string {
+	msg := e.Message
+	if e.Code != "" {
+		msg = e.Code + ": " + msg
+	}
+	if e.cause != nil {
+		msg += ": " + e.cause.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the wrapped cause error (if any).
+func (e ErrorPayload) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an ErrorPayload with the same code.
+//
+// Example:
+//
+//	errors.Is(err, reply.NotFound("")) // true for any NOT_FOUND error
+func (e ErrorPayload) Is(target error) bool {
+	switch t := target.(type) {
+	case ErrorPayload:
+		return e.Code == t.Code
+	case *ErrorPayload:
+		return t != nil && e.Code == t.Code
+	}
+	return false
+}
+
+// AsErrorPayload finds the first ErrorPayload in the err chain, returned as a value or a pointer.
+//
+// Example:
+//
+//	payload, ok := reply.AsErrorPayload(fmt.Errorf("load user: %w", reply.NotFound("User not found")))
+//	// payload.Code == "NOT_FOUND", ok == true
+func AsErrorPayload(err error) (ErrorPayload, bool) {
+	var ptr *ErrorPayload
+	if errors.As(err, &ptr) && ptr != nil {
+		return *ptr, true
+	}
+	var val ErrorPayload
+	if errors.As(err, &val) {
+		return val, true
+	}
+	return ErrorPayload{}, false
+}
+
+// statusCodes maps HTTP status codes to the error codes of the typed constructors.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          "BAD_REQUEST",
+	http.StatusUnauthorized:        "UNAUTHORIZED",
+	http.StatusForbidden:           "FORBIDDEN",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusConflict:            "CONFLICT",
+	http.StatusUnprocessableEntity: "UNPROCESSABLE_ENTITY",
+	http.StatusTooManyRequests:     "TOO_MANY_REQUESTS",
+	http.StatusInternalServerError: "SERVER_ERROR",
+	http.StatusServiceUnavailable:  "SERVICE_UNAVAILABLE",
+}
+
+// StatusError creates an error payload with a code derived from the HTTP status code,
+// e.g. 404 -> "NOT_FOUND", 405 -> "METHOD_NOT_ALLOWED". An empty message defaults to the status text.
+// Useful to convert framework errors carrying only a status code.
+//
+// Example:
+//
+//	reply.StatusError(http.StatusMethodNotAllowed, "") // METHOD_NOT_ALLOWED: Method Not Allowed
+func StatusError(status int, message string, options ...ErrorOption) *ErrorPayload {
+	code, ok := statusCodes[status]
+	if !ok {
+		code = strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(http.StatusText(status)))
+	}
+	if code == "" {
+		code = "ERROR"
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return NewError(status, code, message, options...)
+}
+
+// NewError creates an error payload with HTTP status code.
+//
+// Example:
+//
+//	return reply.NewError(http.StatusPaymentRequired, "PAYMENT_REQUIRED", "Upgrade your plan")
+func NewError(status int, code, message string, options ...ErrorOption) *ErrorPayload {
+	payload := &ErrorPayload{Code: code, Message: message, Status: status}
+	for _, opt := range options {
+		opt(payload)
+	}
+	return payload
+}
+
+// BadRequest creates a "BAD_REQUEST" error with status 400.
+//
+// Example:
+//
+//	return reply.BadRequest("Invalid input", reply.WithFields(reply.FieldsError{"email": "invalid email"}))
+func BadRequest(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusBadRequest, "BAD_REQUEST", message, options...)
+}
+
+// Unauthorized creates an "UNAUTHORIZED" error with status 401.
+func Unauthorized(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusUnauthorized, "UNAUTHORIZED", message, options...)
+}
+
+// Forbidden creates a "FORBIDDEN" error with status 403.
+func Forbidden(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusForbidden, "FORBIDDEN", message, options...)
+}
+
+// NotFound creates a "NOT_FOUND" error with status 404.
+//
+// Example:
+//
+//	return reply.NotFound("User not found")
+func NotFound(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusNotFound, "NOT_FOUND", message, options...)
+}
+
+// Conflict creates a "CONFLICT" error with status 409.
+func Conflict(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusConflict, "CONFLICT", message, options...)
+}
+
+// UnprocessableEntity creates an "UNPROCESSABLE_ENTITY" error with status 422.
+func UnprocessableEntity(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", message, options...)
+}
+
+// TooManyRequests creates a "TOO_MANY_REQUESTS" error with status 429.
+func TooManyRequests(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusTooManyRequests, "TOO_MANY_REQUESTS", message, options...)
+}
+
+// InternalError creates a "SERVER_ERROR" error with status 500.
+//
+// Example:
+//
+//	return reply.InternalError("Failed to save user", reply.WithCause(err))
+func InternalError(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusInternalServerError, "SERVER_ERROR", message, options...)
+}
+
+// ServiceUnavailable creates a "SERVICE_UNAVAILABLE" error with status 503.
+func ServiceUnavailable(message string, options ...ErrorOption) *ErrorPayload {
+	return NewError(http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", message, options...)
+}
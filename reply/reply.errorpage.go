@@ -0,0 +1,104 @@
+package reply
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+)
+
+// builtinErrorPage is the built-in HTML error page, used without a matching Client.ErrorPages template.
+var builtinErrorPage = template.Must(template.New("error").Funcs(template.FuncMap{
+	"json": func(v any) string {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err.Error()
+		}
+		return string(b)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Data.Status}} {{.Data.Title}}</title>
+<style>
+body{margin:0;min-height:100vh;display:flex;align-items:center;justify-content:center;background:#f6f7f9;color:#1f2328;font:16px/1.5 system-ui,-apple-system,"Segoe UI",sans-serif}
+main{max-width:40rem;width:100%;margin:2rem;padding:2rem 2.5rem;background:#fff;border-radius:12px;box-shadow:0 1px 3px rgba(0,0,0,.08),0 8px 24px rgba(0,0,0,.06)}
+.status{margin:0;font-size:3.5rem;font-weight:700;color:#cf222e;line-height:1}
+h1{margin:.5rem 0 0;font-size:1.5rem}
+p{margin:1rem 0 0;color:#59636e}
+code{font:.85rem ui-monospace,SFMono-Regular,Menlo,monospace;background:#eff1f3;padding:.15rem .4rem;border-radius:4px}
+ul{margin:1rem 0 0;padding-left:1.25rem}
+pre{margin:1rem 0 0;padding:1rem;overflow:auto;background:#1f2328;color:#e6edf3;border-radius:8px;font:.8rem/1.5 ui-monospace,SFMono-Regular,Menlo,monospace}
+</style>
+</head>
+<body>
+<main>
+<p class="status">{{.Data.Status}}</p>
+<h1>{{.Data.Title}}</h1>
+<p>{{.Data.Message}}</p>
+{{- if .Data.Code}}
+<p><code>{{.Data.Code}}</code></p>
+{{- end}}
+{{- with .Data.Fields}}
+<ul>
+{{- range $field, $err := .}}
+<li><code>{{$field}}</code> {{$err}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- with .Data.Details}}
+<pre>{{.}}</pre>
+{{- end}}
+{{- with .Meta.Debug}}
+<pre>{{json .}}</pre>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+// errorPage builds the HTML error page data from the error payload.
+// If data is not an ErrorPayload, the page is built from the status code only.
+func (r *Reply) errorPage(status int) ErrorPage {
+	page := ErrorPage{
+		Status:  status,
+		Title:   http.StatusText(status),
+		Message: http.StatusText(status),
+	}
+
+	if d, ok := r.m.Data.(ErrorPayload); ok {
+		page.Code = d.Code
+		page.Fields = d.Fields
+		if d.Message != "" {
+			page.Message = d.Message
+		}
+		if r.c.DebugMode {
+			page.Details = d.Details
+		}
+	}
+	return page
+}
+
+// errorPageTemplate returns the template of the status error page and the name to execute it with.
+// Falls back to the built-in page if no Client.ErrorPages template matches.
+func (c *Client) errorPageTemplate(status int) (*template.Template, string) {
+	name, ok := c.ErrorPages[status]
+	if !ok {
+		name, ok = c.ErrorPages[0]
+	}
+	if !ok {
+		return builtinErrorPage, "error"
+	}
+	if c.templates == nil {
+		logError(ErrNoTemplates, 4)
+		return builtinErrorPage, "error"
+	}
+
+	page, exec, err := c.templates.lookup(name)
+	if err != nil {
+		logError(err, 4)
+		return builtinErrorPage, "error"
+	}
+	return page, exec
+}
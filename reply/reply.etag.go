@@ -0,0 +1,143 @@
+package reply
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ETag sets the ETag of the reply, e.g. from a version field.
+// The tag is quoted if needed; weak tags keep their W/ prefix.
+// Encoded replies answer 304 Not Modified when If-None-Match matches.
+//
+// Example:
+//
+//	rp.Success(user).ETag(strconv.Itoa(user.Version)).OkJSON() // ETag: "7"
+func (r *Reply) ETag(tag string) *Reply {
+	r.etag = quoteETag(tag)
+	return r
+}
+
+// AutoETag computes the ETag of this reply from its encoded payload, overriding Client.ETag.
+// Meta.Timestamp is left out, so equal data gets equal tags.
+//
+// Example:
+//
+//	rp.Success(users).AutoETag(reply.ETagWeak).OkJSON() // ETag: W/"5d41..."
+func (r *Reply) AutoETag(mode ETagMode) *Reply {
+	r.etagMode = mode
+	return r
+}
+
+// LastModified sets the Last-Modified header of the reply, e.g. from an updated-at field.
+// Encoded replies answer 304 Not Modified when If-Modified-Since is not older,
+// unless If-None-Match is sent.
+//
+// Example:
+//
+//	rp.Success(user).LastModified(user.UpdatedAt).OkJSON()
+func (r *Reply) LastModified(t time.Time) *Reply {
+	r.lastModified = t
+	return r
+}
+
+// quoteETag wraps a tag in quotes unless it already is an entity tag.
+func quoteETag(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	opaque, weak := strings.CutPrefix(tag, "W/")
+	if len(opaque) >= 2 && opaque[0] == '"' && opaque[len(opaque)-1] == '"' {
+		return tag
+	}
+	if weak {
+		return `W/"` + opaque + `"`
+	}
+	return `"` + tag + `"`
+}
+
+// etagMatch reports whether the If-None-Match or If-Match list contains etag.
+// Weak comparison ignores W/ prefixes. "*" matches any current representation,
+// even one without an ETag.
+func etagMatch(list, etag string, weak bool) bool {
+	opaque, isWeak := strings.CutPrefix(etag, "W/")
+	for candidate := range strings.SplitSeq(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if etag == "" || (!weak && isWeak) {
+			continue
+		}
+		c, cWeak := strings.CutPrefix(candidate, "W/")
+		if !weak && cWeak {
+			continue
+		}
+		if c == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// entityTag returns the ETag of an encoded reply: the caller-supplied one,
+// or one computed from the encoded Payload. Returns "" if none applies.
+// A transformed Payload is encoded into body and hashed as sent. The built-in envelope is hashed
+// without Meta.Timestamp, which changes every second, and body is left empty so it is only
+// encoded if the request is not answered with 304 Not Modified.
+func (r *Reply) entityTag(format Format, enc Encoder, body *bytes.Buffer) (string, error) {
+	if r.etag != "" {
+		return r.etag, nil
+	}
+	mode := r.etagMode
+	if mode == "" {
+		mode = r.c.ETag
+	}
+	if mode != ETagStrong && mode != ETagWeak {
+		return "", nil
+	}
+
+	hashed := body
+	if envelope, ok := r.Payload.(*ReplyEnvelope); ok {
+		untimed := *envelope
+		untimed.Meta.Timestamp = 0
+		hashed = &bytes.Buffer{}
+		if err := enc.Encode(hashed, &untimed); err != nil {
+			return "", err
+		}
+	} else if err := enc.Encode(body, r.Payload); err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(format))
+	h.Write(hashed.Bytes())
+	tag := `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+	if mode == ETagWeak {
+		return "W/" + tag, nil
+	}
+	return tag, nil
+}
+
+// setValidators sets the ETag and Last-Modified headers and reports
+// whether the request is answered with 304 Not Modified.
+func (r *Reply) setValidators(etag string, modtime time.Time) bool {
+	if etag != "" {
+		r.a.SetHeader("ETag", etag)
+	}
+	if !isZeroTime(modtime) {
+		r.a.SetHeader("Last-Modified", modtime.UTC().Format(http.TimeFormat))
+	}
+	return r.notModified(etag, modtime)
+}
+
+// replyNotModified sends 304 Not Modified without body.
+func (r *Reply) replyNotModified() error {
+	r.a.DelHeader("Content-Type")
+	r.a.DelHeader("Content-Length")
+	r.a.SetStatus(http.StatusNotModified)
+	return nil
+}
@@ -0,0 +1,167 @@
+package reply_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+func TestReplyETag(t *testing.T) {
+	modtime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		build  func(rp *reply.Reply) *reply.Reply
+		fail   bool // sends with Fail instead of Ok
+		status int
+		etag   string
+	}{
+		{
+			name:   "Quoted",
+			build:  func(rp *reply.Reply) *reply.Reply { return rp.ETag("7") },
+			status: http.StatusOK,
+			etag:   `"7"`,
+		},
+		{
+			name:   "Match",
+			header: map[string]string{"If-None-Match": `"6", "7"`},
+			build:  func(rp *reply.Reply) *reply.Reply { return rp.ETag(`"7"`) },
+			status: http.StatusNotModified,
+			etag:   `"7"`,
+		},
+		{
+			name:   "Mismatch",
+			header: map[string]string{"If-None-Match": `"6"`},
+			build:  func(rp *reply.Reply) *reply.Reply { return rp.ETag("7") },
+			status: http.StatusOK,
+			etag:   `"7"`,
+		},
+		{
+			name:   "WeakComparison",
+			header: map[string]string{"If-None-Match": `"7"`},
+			build:  func(rp *reply.Reply) *reply.Reply { return rp.ETag("W/7") },
+			status: http.StatusNotModified,
+			etag:   `W/"7"`,
+		},
+		{
+			name:   "Wildcard",
+			header: map[string]string{"If-None-Match": "*"},
+			build:  func(rp *reply.Reply) *reply.Reply { return rp },
+			status: http.StatusNotModified,
+		},
+		{
+			name:   "LastModifiedNotModified",
+			header: map[string]string{"If-Modified-Since": modtime.Format(http.TimeFormat)},
+			build:  func(rp *reply.Reply) *reply.Reply { return rp.LastModified(modtime) },
+			status: http.StatusNotModified,
+		},
+		{
+			name:   "LastModifiedModified",
+			header: map[string]string{"If-Modified-Since": modtime.Add(-time.Hour).Format(http.TimeFormat)},
+			build:  func(rp *reply.Reply) *reply.Reply { return rp.LastModified(modtime) },
+			status: http.StatusOK,
+		},
+		{
+			name:   "IfNoneMatchWinsOverDate",
+			header: map[string]string{"If-None-Match": `"6"`, "If-Modified-Since": modtime.Format(http.TimeFormat)},
+			build:  func(rp *reply.Reply) *reply.Reply { return rp.ETag("7").LastModified(modtime) },
+			status: http.StatusOK,
+			etag:   `"7"`,
+		},
+		{
+			name:   "ErrorReplyWithoutValidators",
+			header: map[string]string{"If-None-Match": "*"},
+			build:  func(rp *reply.Reply) *reply.Reply { return rp.Error("NOT_FOUND", "User not found").ETag("7") },
+			fail:   true,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			client := reply.NewClient(reply.Client{CodeAliases: reply.CodeAliases{"NOT_FOUND": http.StatusNotFound}})
+			rp, rec := replytest.New(client, req)
+			rp.Success(streamItem{ID: 42, Name: "Jane"})
+			send := tt.build(rp).Ok
+			if tt.fail {
+				send = func() error { return rp.Fail() }
+			}
+			if err := send(); err != nil {
+				t.Fatalf("send: %v", err)
+			}
+
+			rec.AssertStatus(t, tt.status).AssertHeader(t, "ETag", tt.etag)
+			if tt.status == http.StatusNotModified {
+				rec.AssertHeader(t, "Content-Type", "")
+				if len(rec.Body()) != 0 {
+					t.Errorf("body = %s, want empty", rec.Body())
+				}
+			}
+		})
+	}
+}
+
+func TestReplyAutoETag(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    reply.Client
+		build     func(rp *reply.Reply) *reply.Reply
+		weak      bool
+		sameTwice bool // equal replies sent twice get equal tags
+	}{
+		{"ClientStrong", reply.Client{ETag: reply.ETagStrong}, func(rp *reply.Reply) *reply.Reply { return rp }, false, true},
+		{"ClientWeak", reply.Client{ETag: reply.ETagWeak}, func(rp *reply.Reply) *reply.Reply { return rp }, true, true},
+		{"PerReply", reply.Client{}, func(rp *reply.Reply) *reply.Reply { return rp.AutoETag(reply.ETagWeak) }, true, true},
+		{
+			name: "TransformedAsSent",
+			client: reply.Client{ETag: reply.ETagStrong, Transformer: func(rp *reply.Reply) any {
+				return map[string]any{"data": rp.Payload, "at": time.Now().UnixNano()}
+			}},
+			build:     func(rp *reply.Reply) *reply.Reply { return rp },
+			sameTwice: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(tt.client)
+			send := func(header string) *replytest.Recorder {
+				req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+				if header != "" {
+					req.Header.Set("If-None-Match", header)
+				}
+				rp, rec := replytest.New(client, req)
+				if err := tt.build(rp.Success(streamItem{ID: 42, Name: "Jane"})).Ok(); err != nil {
+					t.Fatalf("Ok: %v", err)
+				}
+				return rec
+			}
+
+			first := send("")
+			etag := first.Header().Get("ETag")
+			if etag == "" || strings.HasPrefix(etag, "W/") != tt.weak {
+				t.Fatalf("ETag = %q, want weak %v", etag, tt.weak)
+			}
+
+			second := send(etag)
+			if tt.sameTwice {
+				second.AssertStatus(t, http.StatusNotModified).AssertHeader(t, "ETag", etag)
+			} else {
+				second.AssertStatus(t, http.StatusOK)
+				if second.Header().Get("ETag") == etag {
+					t.Errorf("ETag = %q for a different body", etag)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package reply
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// errorType is the reflect type of the error interface.
+var errorType = reflect.TypeFor[error]()
+
+// eachFunc calls fn with each element of a sequence.
+// It stops at the first error of fn, an error yielded by the sequence, or ctx cancellation.
+// seqErr is the error yielded by the sequence, err is the error of fn or ctx.
+type eachFunc func(ctx context.Context, fn func(v any) error) (seqErr, err error)
+
+// streamError holds the error yielded by a streamed sequence, returned by the send method.
+// Some adapters write the body after the handler returns, so access is guarded.
+type streamError struct {
+	mu  sync.Mutex
+	err error
+}
+
+// set stores err.
+func (s *streamError) set(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// get returns the stored error, nil while the body is not written yet.
+func (s *streamError) get() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// elements returns an eachFunc over data, which may be an iter.Seq[T], iter.Seq2[T, error],
+// a receive channel, a slice or an array.
+func elements(data any) (eachFunc, bool) {
+	v := reflect.ValueOf(data)
+	if !v.IsValid() {
+		return nil, false
+	}
+
+	switch v.Kind() {
+	case reflect.Func:
+		return seqElements(v)
+	case reflect.Chan:
+		if v.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, false
+		}
+		return chanElements(v), true
+	case reflect.Slice, reflect.Array:
+		return sliceElements(v), true
+	default:
+		return nil, false
+	}
+}
+
+// seqElements iterates an iter.Seq[T] or iter.Seq2[T, error] by reflection.
+func seqElements(seq reflect.Value) (eachFunc, bool) {
+	t := seq.Type()
+	if t.NumIn() != 1 || t.NumOut() != 0 {
+		return nil, false
+	}
+	yieldType := t.In(0)
+	if yieldType.Kind() != reflect.Func || yieldType.NumOut() != 1 || yieldType.Out(0).Kind() != reflect.Bool {
+		return nil, false
+	}
+	switch yieldType.NumIn() {
+	case 1:
+	case 2:
+		if yieldType.In(1) != errorType {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	return func(ctx context.Context, fn func(v any) error) (seqErr, err error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		stopped := false
+		yield := reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+			// misbehaving sequences may keep yielding after a stop
+			if stopped {
+				return []reflect.Value{reflect.ValueOf(false)}
+			}
+			if len(args) == 2 && !args[1].IsNil() {
+				seqErr = args[1].Interface().(error)
+			} else if err = ctx.Err(); err == nil {
+				err = fn(args[0].Interface())
+			}
+			stopped = seqErr != nil || err != nil
+			return []reflect.Value{reflect.ValueOf(!stopped)}
+		})
+		seq.Call([]reflect.Value{yield})
+		return seqErr, err
+	}, true
+}
+
+// chanElements receives from a channel until it is closed or ctx is done.
+func chanElements(ch reflect.Value) eachFunc {
+	return func(ctx context.Context, fn func(v any) error) (error, error) {
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+			{Dir: reflect.SelectRecv, Chan: ch},
+		}
+		for {
+			chosen, v, ok := reflect.Select(cases)
+			if chosen == 0 {
+				return nil, ctx.Err()
+			}
+			if !ok {
+				return nil, nil
+			}
+			if err := fn(v.Interface()); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// sliceElements walks a slice or an array.
+func sliceElements(s reflect.Value) eachFunc {
+	return func(ctx context.Context, fn func(v any) error) (error, error) {
+		for i := range s.Len() {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := fn(s.Index(i).Interface()); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+}
@@ -14,6 +14,9 @@ func (r *Reply) retrieveStatusCode(code ...int) (c int, ok bool) {
 		return code[0], true
 	}
 	d, ok := r.m.Data.(ErrorPayload)
+	if ok && d.Status != 0 {
+		return d.Status, true
+	}
 	if r.c.CodeAliases != nil && ok {
 		if code, exists := r.c.CodeAliases[d.Code]; exists {
 			return code, true
@@ -53,7 +56,7 @@ func (r *Reply) finalize() {
 		r.Payload = r.c.Transformer(r)
 	} else {
 		envelope := &ReplyEnvelope{Meta: r.m.Meta, Data: r.m.Data}
-		if r.c.DebugMode {
+		if !r.c.DebugMode {
 			envelope.Meta.Debug = nil
 		}
 		r.Payload = envelope
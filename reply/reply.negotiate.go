@@ -0,0 +1,187 @@
+package reply
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatMediaTypes maps built-in formats to the media types they can produce.
+// Formats with a registered encoder use the media types given on registration.
+var formatMediaTypes = map[Format][]string{
+	FormatJSON: {"application/json"},
+	FormatXML:  {"application/xml", "text/xml"},
+	FormatText: {"text/plain"},
+	FormatHTML: {"text/html"},
+}
+
+// problemMediaTypes maps formats to the media types they can produce for problem details.
+// HTML renders an error page instead.
+var problemMediaTypes = map[Format][]string{
+	FormatJSON: {"application/problem+json", "application/json"},
+	FormatXML:  {"application/problem+xml", "application/xml", "text/xml"},
+	FormatHTML: {"text/html"},
+}
+
+// problemMediaTypes returns the media types a format can produce for problem details.
+// Formats of registered encoders produce their usual media types.
+func (c *Client) problemMediaTypes(format Format) []string {
+	if types, ok := problemMediaTypes[format]; ok {
+		return types
+	}
+	return c.mediaTypes(format)
+}
+
+// acceptRange is a single media range of an Accept header.
+type acceptRange struct {
+	typ     string  // e.g. "application" or "*"
+	subtype string  // e.g. "json" or "*"
+	q       float64 // quality value in range 0..1
+}
+
+// specificity ranks how precise the media range is, "*/*" being the least.
+func (a acceptRange) specificity() int {
+	switch {
+	case a.typ == "*":
+		return 0
+	case a.subtype == "*":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// match reports whether the media range includes the given media type.
+func (a acceptRange) match(typ, subtype string) bool {
+	if a.typ == "*" {
+		return true
+	}
+	return a.typ == typ && (a.subtype == "*" || a.subtype == subtype)
+}
+
+// parseAccept parses an Accept header value into media ranges.
+// Malformed ranges are skipped.
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for part := range strings.SplitSeq(header, ",") {
+		params := strings.Split(part, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+		if mediaRange == "" {
+			continue
+		}
+		if mediaRange == "*" {
+			mediaRange = "*/*"
+		}
+
+		typ, subtype, ok := strings.Cut(mediaRange, "/")
+		if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		ar := acceptRange{typ: typ, subtype: subtype, q: 1}
+		valid := true
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(strings.ToLower(key)) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+				break
+			}
+			ar.q = q
+		}
+		if valid {
+			ranges = append(ranges, ar)
+		}
+	}
+
+	// most specific ranges first so quality lookups stop at the best match
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].specificity() > ranges[j].specificity()
+	})
+	return ranges
+}
+
+// quality returns the q-value the ranges grant to a media type.
+// The most specific matching range wins. Returns 0 if no range matches.
+func quality(ranges []acceptRange, mediaType string) float64 {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	for _, ar := range ranges {
+		if ar.match(typ, subtype) {
+			return ar.q
+		}
+	}
+	return 0
+}
+
+// negotiateFormat picks the offer with the highest quality for the Accept header,
+// looking up the media types of each offer with mediaTypes.
+// Ties are resolved by offer order, so the preferred format must come first.
+// An empty header accepts the first offer.
+func negotiateFormat(accept string, offers []Format, mediaTypes func(Format) []string) (Format, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+
+	ranges := parseAccept(accept)
+	var best Format
+	var bestQ float64
+	for _, offer := range offers {
+		for _, mediaType := range mediaTypes(offer) {
+			if q := quality(ranges, mediaType); q > bestQ {
+				best, bestQ = offer, q
+			}
+		}
+	}
+	return best, bestQ > 0
+}
+
+// defaultFormat returns the configured fallback format.
+func (c *Client) defaultFormat() Format {
+	if c.DefaultFormat == "" {
+		return FormatJSON
+	}
+	return c.DefaultFormat
+}
+
+// offers lists formats able to render the current data, preferred format first.
+// Formats of registered encoders follow JSON and XML. Text and HTML can only render string data,
+// and HTML renders error payloads as error pages.
+func (r *Reply) offers() []Format {
+	candidates := []Format{FormatJSON, FormatXML}
+	for _, f := range r.c.formats {
+		if !slices.Contains(candidates, f) && f != FormatText && f != FormatHTML {
+			candidates = append(candidates, f)
+		}
+	}
+	switch r.m.Data.(type) {
+	case string:
+		candidates = append(candidates, FormatHTML, FormatText)
+	case ErrorPayload:
+		candidates = append(candidates, FormatHTML)
+	}
+	return r.c.preferDefault(candidates)
+}
+
+// preferDefault moves the default format to the front of candidates.
+func (c *Client) preferDefault(candidates []Format) []Format {
+	def := c.defaultFormat()
+	offers := make([]Format, 0, len(candidates))
+	for _, f := range candidates {
+		if f == def {
+			offers = append(offers, f)
+		}
+	}
+	for _, f := range candidates {
+		if f != def {
+			offers = append(offers, f)
+		}
+	}
+	return offers
+}
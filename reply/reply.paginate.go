@@ -1,7 +1,12 @@
 package reply
 
 import (
+	"math"
+	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // Create pagination information in meta data with total based.
@@ -37,6 +42,7 @@ func (r *Reply) PaginateTotal(limit, current, total int) *Reply {
 		Current: current,
 		Total:   total,
 	}
+	r.paginationLinks(limit, (total+limit-1)/limit, true)
 	return r
 }
 
@@ -51,11 +57,81 @@ func (r *Reply) PaginateCursor(limit, current int) *Reply {
 		limit = 1
 	}
 
+	_, hasNext, ok := r.cutData(limit)
+	if !ok {
+		return r
+	}
+
+	// set next by pagination type
+	var next int
+	if hasNext {
+		if r.c.PaginationType == PaginationPage {
+			next = current + 1
+		} else {
+			next = current + limit
+		}
+	}
+
+	r.m.Meta.Pagination = &Pagination{
+		Next:    next,
+		HasNext: hasNext,
+		Current: current,
+	}
+	r.paginationLinks(limit, 0, false)
+	return r
+}
+
+// PaginateKeyset creates keyset pagination information in meta keyset (data to send + 1).
+// Auto cut data. key extracts the keyset of the last sent element, e.g. its (created_at, id),
+// which is encoded as the opaque next cursor, signed if Client.CursorSecret is set.
+// Read the cursor of the next request with DecodeCursor.
+// Returns the error if the key can not be encoded, leaving the meta unchanged.
+//
+// Example:
+//
+//	rp, err := rp.Success(users).PaginateKeyset(limit, func(last any) any {
+//		u := last.(User)
+//		return UserKey{CreatedAt: u.CreatedAt, ID: u.ID}
+//	})
+//	if err != nil {
+//		return rp.Err(err).Fail()
+//	}
+//	return rp.OkJSON()
+func (r *Reply) PaginateKeyset(limit int, key func(last any) any) (*Reply, error) {
+	if limit <= 0 {
+		limit = 1
+	}
+
+	v, hasNext, ok := r.cutData(limit)
+	if !ok {
+		return r, nil
+	}
+
+	var cursor string
+	if hasNext {
+		var err error
+		cursor, err = r.c.encodeCursor(key(v.Index(limit - 1).Interface()))
+		if err != nil {
+			return r, err
+		}
+	}
+
+	r.m.Meta.Keyset = &KeysetPagination{
+		HasNext:    hasNext,
+		NextCursor: cursor,
+	}
+	r.keysetLinks(limit, cursor)
+	return r, nil
+}
+
+// cutData cuts slice data to limit elements and returns the cut slice,
+// reporting whether more elements exist. Returns false if data is not a slice.
+func (r *Reply) cutData(limit int) (reflect.Value, bool, bool) {
 	v := reflect.ValueOf(r.m.Data)
 
 	// returns zero value prevents panic
 	if !v.IsValid() {
-		return r
+		return v, false, false
 	}
 
 	// unwrap value
@@ -65,31 +141,215 @@ func (r *Reply) PaginateCursor(limit, current int) *Reply {
 
 	// guard type
 	if v.Kind() != reflect.Slice {
-		return r
+		return v, false, false
 	}
 
-	dataLen := v.Len()
-	hasNext := dataLen > limit
+	hasNext := v.Len() > limit
 
 	// cut data
 	if hasNext {
-		r.m.Data = v.Slice(0, limit).Interface()
+		v = v.Slice(0, limit)
+		r.m.Data = v.Interface()
 	}
+	return v, hasNext, true
+}
 
-	// set next by pagination type
-	var next int
-	if hasNext {
-		if r.c.PaginationType == PaginationPage {
-			next = current + 1
+// paginationLinks adds the navigation links of the current pagination,
+// as Link headers and meta details when enabled on the client.
+// The last link is only built if the total is known.
+func (r *Reply) paginationLinks(limit, totalPages int, hasTotal bool) {
+	if !r.c.PaginationLinks && !r.c.PaginationDetails {
+		return
+	}
+	p := r.m.Meta.Pagination
+
+	// first and last page/offset by pagination type
+	first, last, step := 0, (max(totalPages, 1)-1)*limit, limit
+	if r.c.PaginationType == PaginationPage {
+		first, last, step = 1, max(totalPages, 1), 1
+	}
+
+	links := PaginationLinks{First: r.pageURL(first)}
+	var prev *int
+	if p.Current > first {
+		v := max(p.Current-step, first)
+		prev = &v
+		links.Prev = r.pageURL(v)
+	}
+	if p.HasNext {
+		links.Next = r.pageURL(p.Next)
+	}
+	if hasTotal {
+		links.Last = r.pageURL(last)
+	}
+
+	if r.c.PaginationLinks {
+		r.a.AddHeader("Link", links.header())
+	}
+	if r.c.PaginationDetails {
+		p.Prev = prev
+		p.TotalPages = totalPages
+		p.Limit = limit
+		p.Links = &links
+	}
+}
+
+// keysetLinks adds the first and next links of keyset pagination,
+// as Link headers and meta details when enabled on the client.
+func (r *Reply) keysetLinks(limit int, cursor string) {
+	if !r.c.PaginationLinks && !r.c.PaginationDetails {
+		return
+	}
+
+	param := r.c.cursorParam()
+	links := PaginationLinks{First: r.paramURL(param, "")}
+	if cursor != "" {
+		links.Next = r.paramURL(param, cursor)
+	}
+
+	if r.c.PaginationLinks {
+		r.a.AddHeader("Link", links.header())
+	}
+	if r.c.PaginationDetails {
+		r.m.Meta.Keyset.Limit = limit
+		r.m.Meta.Keyset.Links = &links
+	}
+}
+
+// pageURL returns the current request URL with the page/offset query parameter set to value.
+func (r *Reply) pageURL(value int) string {
+	return r.paramURL(r.c.paginationParam(), strconv.Itoa(value))
+}
+
+// paramURL returns the current request URL with the query parameter key set to value.
+// An empty value removes the parameter.
+func (r *Reply) paramURL(key, value string) string {
+	query := url.Values{}
+	for k, v := range r.a.Query() {
+		query[k] = v
+	}
+	if value == "" {
+		query.Del(key)
+	} else {
+		query.Set(key, value)
+	}
+	if len(query) == 0 {
+		return r.a.Path()
+	}
+	return r.a.Path() + "?" + query.Encode()
+}
+
+// paginationParam returns the query parameter of the page or offset.
+func (c *Client) paginationParam() string {
+	if c.PaginationParam != "" {
+		return c.PaginationParam
+	}
+	if c.PaginationType == PaginationPage {
+		return "page"
+	}
+	return "offset"
+}
+
+// header formats the links as an RFC 8288 Link header value.
+//
+// Example:
+//
+//	</users?page=1>; rel="first", </users?page=3>; rel="next"
+func (l PaginationLinks) header() string {
+	var parts []string
+	for _, link := range []struct{ url, rel string }{
+		{l.First, "first"}, {l.Prev, "prev"}, {l.Next, "next"}, {l.Last, "last"},
+	} {
+		if link.url != "" {
+			parts = append(parts, "<"+link.url+">; rel=\""+link.rel+"\"")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// PageParams reads the page size, page or offset and cursor query parameters of the request
+// according to Client.PaginationType. An omitted limit defaults to Client.DefaultPageSize,
+// and limits above Client.MaxPageSize are capped.
+// Invalid values, including pages whose offset overflows, set a "BAD_REQUEST" error with the fields at fault and return false.
+//
+// Example:
+//
+//	params, ok := rp.PageParams()
+//	if !ok {
+//		return rp.Fail() // 400 BAD_REQUEST, fields: {"limit": "must be a positive integer"}
+//	}
+//	users, total := fetchUsers(params.Limit, params.Offset)
+//	return rp.Success(users).PaginateTotal(params.Limit, params.Current, total).OkJSON()
+func (r *Reply) PageParams() (PageParams, bool) {
+	query := r.a.Query()
+	fields := FieldsError{}
+
+	params := PageParams{Limit: r.c.defaultPageSize(), Cursor: query.Get(r.c.cursorParam())}
+	limitParam := r.c.limitParam()
+	if raw := query.Get(limitParam); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			fields[limitParam] = "must be a positive integer"
 		} else {
-			next = current + limit
+			params.Limit = min(limit, r.c.maxPageSize())
 		}
 	}
 
-	r.m.Meta.Pagination = &Pagination{
-		Next:    next,
-		HasNext: hasNext,
-		Current: current,
+	param := r.c.paginationParam()
+	raw := query.Get(param)
+	if r.c.PaginationType == PaginationPage {
+		params.Current = 1
+		if raw != "" {
+			page, err := strconv.Atoi(raw)
+			if err != nil || page < 1 {
+				fields[param] = "must be a positive integer"
+			} else if page-1 > math.MaxInt/params.Limit {
+				// offset of the page overflows int
+				fields[param] = "is too large"
+			} else {
+				params.Current = page
+			}
+		}
+		params.Offset = (params.Current - 1) * params.Limit
+	} else {
+		if raw != "" {
+			offset, err := strconv.Atoi(raw)
+			if err != nil || offset < 0 {
+				fields[param] = "must be a non-negative integer"
+			} else {
+				params.Current = offset
+			}
+		}
+		params.Offset = params.Current
 	}
-	return r
+
+	if len(fields) > 0 {
+		r.Error("BAD_REQUEST", "Invalid pagination parameters", WithFields(fields), WithStatus(http.StatusBadRequest))
+		return PageParams{}, false
+	}
+	return params, true
+}
+
+// limitParam returns the query parameter of the page size.
+func (c *Client) limitParam() string {
+	if c.LimitParam != "" {
+		return c.LimitParam
+	}
+	return "limit"
+}
+
+// defaultPageSize returns the page size used when the limit is omitted, within the maximum.
+func (c *Client) defaultPageSize() int {
+	if c.DefaultPageSize > 0 {
+		return min(c.DefaultPageSize, c.maxPageSize())
+	}
+	return min(20, c.maxPageSize())
+}
+
+// maxPageSize returns the largest page size.
+func (c *Client) maxPageSize() int {
+	if c.MaxPageSize > 0 {
+		return c.MaxPageSize
+	}
+	return 100
 }
@@ -0,0 +1,175 @@
+package reply_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+// intPtr returns a pointer to v.
+func intPtr(v int) *int { return &v }
+
+func TestPaginationLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   reply.Client
+		target   string
+		paginate func(rp *reply.Reply) *reply.Reply
+		link     string
+		want     reply.Pagination
+	}{
+		{
+			name:     "PageTotal",
+			client:   reply.Client{PaginationType: reply.PaginationPage, PaginationLinks: true},
+			target:   "/users?page=2&q=go",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateTotal(10, 2, 45) },
+			link:     `</users?page=1&q=go>; rel="first", </users?page=1&q=go>; rel="prev", </users?page=3&q=go>; rel="next", </users?page=5&q=go>; rel="last"`,
+			want:     reply.Pagination{Next: 3, HasNext: true, Current: 2, Total: 45},
+		},
+		{
+			name:     "PageFirst",
+			client:   reply.Client{PaginationType: reply.PaginationPage, PaginationLinks: true},
+			target:   "/users",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateTotal(10, 1, 45) },
+			link:     `</users?page=1>; rel="first", </users?page=2>; rel="next", </users?page=5>; rel="last"`,
+			want:     reply.Pagination{Next: 2, HasNext: true, Current: 1, Total: 45},
+		},
+		{
+			name:     "PageLast",
+			client:   reply.Client{PaginationType: reply.PaginationPage, PaginationLinks: true},
+			target:   "/users?page=5",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateTotal(10, 5, 45) },
+			link:     `</users?page=1>; rel="first", </users?page=4>; rel="prev", </users?page=5>; rel="last"`,
+			want:     reply.Pagination{Current: 5, Total: 45},
+		},
+		{
+			name:     "OffsetTotal",
+			client:   reply.Client{PaginationLinks: true},
+			target:   "/users?offset=20",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateTotal(10, 20, 45) },
+			link:     `</users?offset=0>; rel="first", </users?offset=10>; rel="prev", </users?offset=30>; rel="next", </users?offset=40>; rel="last"`,
+			want:     reply.Pagination{Next: 30, HasNext: true, Current: 20, Total: 45},
+		},
+		{
+			name:     "OffsetCursorWithoutLast",
+			client:   reply.Client{PaginationLinks: true},
+			target:   "/users?offset=5",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateCursor(10, 5) },
+			link:     `</users?offset=0>; rel="first", </users?offset=0>; rel="prev", </users?offset=15>; rel="next"`,
+			want:     reply.Pagination{Next: 15, HasNext: true, Current: 5},
+		},
+		{
+			name:     "CustomParam",
+			client:   reply.Client{PaginationType: reply.PaginationPage, PaginationLinks: true, PaginationParam: "p"},
+			target:   "/users?p=1",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateTotal(10, 1, 5) },
+			link:     `</users?p=1>; rel="first", </users?p=1>; rel="last"`,
+			want:     reply.Pagination{Current: 1, Total: 5},
+		},
+		{
+			name:     "EmptyTotal",
+			client:   reply.Client{PaginationType: reply.PaginationPage, PaginationLinks: true},
+			target:   "/users",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateTotal(10, 1, 0) },
+			link:     `</users?page=1>; rel="first", </users?page=1>; rel="last"`,
+			want:     reply.Pagination{Current: 1},
+		},
+		{
+			name:     "Details",
+			client:   reply.Client{PaginationType: reply.PaginationPage, PaginationDetails: true},
+			target:   "/users?page=2",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateTotal(10, 2, 45) },
+			want: reply.Pagination{
+				Next: 3, HasNext: true, Current: 2, Total: 45,
+				Prev: intPtr(1), TotalPages: 5, Limit: 10,
+				Links: &reply.PaginationLinks{First: "/users?page=1", Prev: "/users?page=1", Next: "/users?page=3", Last: "/users?page=5"},
+			},
+		},
+		{
+			name:     "Disabled",
+			target:   "/users?offset=20",
+			paginate: func(rp *reply.Reply) *reply.Reply { return rp.PaginateTotal(10, 20, 45) },
+			want:     reply.Pagination{Next: 30, HasNext: true, Current: 20, Total: 45},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := replytest.New(reply.NewClient(tt.client), httptest.NewRequest(http.MethodGet, tt.target, nil))
+			data := make([]int, 11)
+			if err := tt.paginate(rp.Success(data)).OkJSON(); err != nil {
+				t.Fatalf("OkJSON: %v", err)
+			}
+
+			rec.AssertHeader(t, "Link", tt.link).AssertPagination(t, tt.want)
+		})
+	}
+}
+
+func TestPageParams(t *testing.T) {
+	page := reply.Client{PaginationType: reply.PaginationPage}
+
+	tests := []struct {
+		name   string
+		client reply.Client
+		query  string
+		want   reply.PageParams
+		fields reply.FieldsError // expected error fields, nil if valid
+	}{
+		{name: "OffsetDefaults", want: reply.PageParams{Limit: 20}},
+		{name: "Offset", query: "limit=10&offset=30&cursor=abc", want: reply.PageParams{Limit: 10, Current: 30, Offset: 30, Cursor: "abc"}},
+		{name: "PageDefaults", client: page, want: reply.PageParams{Limit: 20, Current: 1}},
+		{name: "Page", client: page, query: "limit=10&page=3", want: reply.PageParams{Limit: 10, Current: 3, Offset: 20}},
+		{name: "LimitCapped", query: "limit=1000", want: reply.PageParams{Limit: 100}},
+		{
+			name:   "CustomParams",
+			client: reply.Client{PaginationType: reply.PaginationPage, PaginationParam: "p", LimitParam: "size", CursorParam: "after", DefaultPageSize: 5, MaxPageSize: 50},
+			query:  "size=80&p=2&after=xyz",
+			want:   reply.PageParams{Limit: 50, Current: 2, Offset: 50, Cursor: "xyz"},
+		},
+		{name: "CustomDefaultSize", client: reply.Client{DefaultPageSize: 5}, want: reply.PageParams{Limit: 5}},
+		{name: "InvalidLimit", query: "limit=abc", fields: reply.FieldsError{"limit": "must be a positive integer"}},
+		{name: "ZeroLimit", query: "limit=0", fields: reply.FieldsError{"limit": "must be a positive integer"}},
+		{name: "NegativeOffset", query: "offset=-1", fields: reply.FieldsError{"offset": "must be a non-negative integer"}},
+		{name: "ZeroPage", client: page, query: "page=0", fields: reply.FieldsError{"page": "must be a positive integer"}},
+		{
+			name:   "InvalidLimitAndPage",
+			client: page,
+			query:  "limit=-5&page=x",
+			fields: reply.FieldsError{"limit": "must be a positive integer", "page": "must be a positive integer"},
+		},
+		{name: "IntOverflow", client: page, query: "page=99999999999999999999", fields: reply.FieldsError{"page": "must be a positive integer"}},
+		{name: "OffsetOverflow", client: page, query: "limit=100&page=9223372036854775807", fields: reply.FieldsError{"page": "is too large"}},
+		{name: "LargestPage", client: page, query: "limit=1&page=9223372036854775807", want: reply.PageParams{Limit: 1, Current: 9223372036854775807, Offset: 9223372036854775806}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := replytest.New(reply.NewClient(tt.client), httptest.NewRequest(http.MethodGet, "/users?"+tt.query, nil))
+			params, ok := rp.PageParams()
+			if ok != (tt.fields == nil) {
+				t.Fatalf("ok = %v, want %v", ok, tt.fields == nil)
+			}
+			if ok {
+				if params != tt.want {
+					t.Errorf("params = %+v, want %+v", params, tt.want)
+				}
+				return
+			}
+
+			if err := rp.Fail(); err != nil {
+				t.Fatalf("Fail: %v", err)
+			}
+			rec.AssertStatus(t, http.StatusBadRequest).AssertErrorCode(t, "BAD_REQUEST")
+			var payload reply.ErrorPayload
+			rec.DecodeData(t, &payload)
+			if !reflect.DeepEqual(payload.Fields, tt.fields) {
+				t.Errorf("fields = %v, want %v", payload.Fields, tt.fields)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package reply
 
-import "reflect"
+import (
+	"encoding/xml"
+	"maps"
+	"reflect"
+	"slices"
+)
 
 // setStatus sets the "meta.status" field value.
 // Returns the Reply for chaining.
@@ -37,6 +42,52 @@ func (r *Reply) Error(code, message string, options ...ErrorOption) *Reply {
 	return r
 }
 
+// Err sets reply status to "ERROR" and attaches an error payload from err.
+// An ErrorPayload in the err chain is attached as is. Otherwise the payload is mapped
+// by the errors registered on the client. Unregistered errors use Client.ErrorFallback;
+// their message is hidden, and attached to debug in DebugMode.
+// A nil err leaves the reply untouched.
+//
+// Example:
+//
+//	Client.RegisterError(sql.ErrNoRows, reply.ErrorMapping{Code: "NOT_FOUND", Message: "Not found", Status: 404})
+//	// ...
+//	rp.Err(err).Fail()
+func (r *Reply) Err(err error) *Reply {
+	if err == nil {
+		return r
+	}
+
+	payload, known := r.errorPayload(err)
+	if !known {
+		r.Debug(err.Error())
+	}
+	r.setStatus("ERROR")
+	r.setData(payload)
+	return r
+}
+
+// errorPayload maps err to an error payload like Err, without changing the reply.
+// Returns false if err is neither an ErrorPayload nor registered, so the fallback is used.
+func (r *Reply) errorPayload(err error) (ErrorPayload, bool) {
+	if payload, ok := AsErrorPayload(err); ok {
+		return payload, true
+	}
+
+	mapping, ok := r.c.lookupError(err)
+	if !ok {
+		mapping = r.c.errorFallback()
+	} else if mapping.Message == "" {
+		mapping.Message = err.Error()
+	}
+
+	payload := ErrorPayload{Code: mapping.Code, Message: mapping.Message}
+	for _, opt := range append([]ErrorOption{WithStatus(mapping.Status), WithCause(err)}, mapping.Options...) {
+		opt(&payload)
+	}
+	return payload, ok
+}
+
 // Info sets info to reply meta information.
 func (r *Reply) Info(information string) *Reply {
 	r.m.Meta.Info = information
@@ -118,3 +169,35 @@ func WithFields(fields FieldsError) ErrorOption {
 		ep.Fields = fields
 	}
 }
+
+// WithStatus returns ErrorOption to build error with HTTP status code, overriding CodeAliases.
+func WithStatus(status int) ErrorOption {
+	return func(ep *ErrorPayload) {
+		ep.Status = status
+	}
+}
+
+// WithCause returns ErrorOption to build error wrapping the cause error.
+func WithCause(err error) ErrorOption {
+	return func(ep *ErrorPayload) {
+		ep.cause = err
+	}
+}
+
+// MarshalXML encodes fields error as <field name="...">message</field> elements sorted by name,
+// since encoding/xml can not marshal maps.
+func (f FieldsError) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, name := range slices.Sorted(maps.Keys(f)) {
+		field := xml.StartElement{
+			Name: xml.Name{Local: "field"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: name}},
+		}
+		if err := e.EncodeElement(f[name], field); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
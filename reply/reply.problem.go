@@ -0,0 +1,69 @@
+package reply
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// problem builds an RFC 9457 problem details document from the error payload.
+// If data is not an ErrorPayload, the document is built from the status code only.
+// With the "about:blank" type, the title is the status text and the message is sent as detail.
+func (r *Reply) problem(status int) Problem {
+	p := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: r.a.Path(),
+	}
+
+	if d, ok := r.m.Data.(ErrorPayload); ok {
+		p.Type = r.c.problemType(d.Code)
+		p.Code = d.Code
+		p.Detail = d.Details
+		p.Errors = d.Fields
+		if p.Type != "about:blank" {
+			if d.Message != "" {
+				p.Title = d.Message
+			}
+		} else if d.Message != "" {
+			p.Detail = d.Message
+			if d.Details != "" {
+				p.Detail += ": " + d.Details
+			}
+		}
+	}
+
+	if r.c.DebugMode {
+		p.Debug = r.m.Meta.Debug
+	}
+	return p
+}
+
+// problemType builds the problem type URI of an error code.
+//
+// Example:
+//
+//	// ProblemTypeURI: "https://example.com/problems"
+//	c.problemType("NOT_FOUND") // -> https://example.com/problems/not-found
+func (c *Client) problemType(code string) string {
+	if c.ProblemTypeURI == "" || code == "" {
+		return "about:blank"
+	}
+	slug := strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+	return strings.TrimRight(c.ProblemTypeURI, "/") + "/" + slug
+}
+
+// debugText renders debug info as text, since XML cannot encode maps such as the Recover debug info.
+// Strings are kept as is, other values are encoded as JSON.
+func debugText(debug any) string {
+	if s, ok := debug.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(debug)
+	if err != nil {
+		return fmt.Sprint(debug)
+	}
+	return string(b)
+}
@@ -0,0 +1,77 @@
+package reply
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// maxStackFrames limits the stack frames attached to debug by Recover.
+const maxStackFrames = 32
+
+// Recover reports a recovered panic to Client.OnPanic and replies with a Client.PanicCode error.
+// In DebugMode, the panic value and a trimmed stack trace are attached to debug.
+// Skips replying and returns ErrAlreadySent if the response was already sent.
+// Used by the recovery middlewares of the adapters.
+//
+// Example:
+//
+//	defer func() {
+//		if v := recover(); v != nil {
+//			Client.Use(nethttpadapter.AdaptHttp(w, r)).Recover(v)
+//		}
+//	}()
+func (r *Reply) Recover(recovered any) error {
+	stack := debug.Stack()
+	if r.c.OnPanic != nil {
+		r.c.OnPanic(r, recovered, stack)
+	}
+	if r.sent {
+		return ErrAlreadySent
+	}
+
+	code := r.c.PanicCode
+	if code == "" {
+		code = "SERVER_ERROR"
+	}
+	r.Error(code, "Internal server error")
+	if r.c.DebugMode {
+		r.Debug(map[string]any{
+			"panic": fmt.Sprint(recovered),
+			"stack": trimStack(stack),
+		})
+	}
+	return r.Fail()
+}
+
+// trimStack returns the frames of a goroutine stack trace below the panic call
+// as "function file:line" lines, without arguments and program counter offsets.
+func trimStack(stack []byte) []string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+
+	// skip goroutine header, or everything up to the panic call
+	start := 1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "panic(") {
+			start = i + 2
+			break
+		}
+	}
+
+	var frames []string
+	for i := start; i+1 < len(lines) && len(frames) < maxStackFrames; i += 2 {
+		fn := lines[i]
+		if strings.HasPrefix(fn, "created by ") {
+			break
+		}
+		if j := strings.LastIndex(fn, "("); j > 0 {
+			fn = fn[:j]
+		}
+		loc := strings.TrimSpace(lines[i+1])
+		if j := strings.LastIndex(loc, " +0x"); j > 0 {
+			loc = loc[:j]
+		}
+		frames = append(frames, fn+" "+loc)
+	}
+	return frames
+}
@@ -0,0 +1,75 @@
+package reply
+
+import (
+	"errors"
+	"net/http"
+)
+
+// errorMatcher pairs an error matcher with its mapping.
+type errorMatcher struct {
+	match   func(err error) bool
+	mapping ErrorMapping
+}
+
+// RegisterError maps errors matching target (by errors.Is) to an error reply.
+// The first registered matching mapping is used by Reply.Err.
+//
+// Example:
+//
+//	Client.RegisterError(sql.ErrNoRows, reply.ErrorMapping{
+//		Code:    "NOT_FOUND",
+//		Message: "Resource not found",
+//		Status:  http.StatusNotFound,
+//	})
+func (c *Client) RegisterError(target error, mapping ErrorMapping) {
+	c.errors = append(c.errors, errorMatcher{
+		match:   func(err error) bool { return errors.Is(err, target) },
+		mapping: mapping,
+	})
+}
+
+// RegisterErrorType maps errors of type E (by errors.As) to an error reply.
+// The first registered matching mapping is used by Reply.Err.
+//
+// Example:
+//
+//	reply.RegisterErrorType[*ValidationError](Client, reply.ErrorMapping{
+//		Code:   "BAD_REQUEST",
+//		Status: http.StatusBadRequest,
+//	})
+func RegisterErrorType[E error](c *Client, mapping ErrorMapping) {
+	c.errors = append(c.errors, errorMatcher{
+		match: func(err error) bool {
+			var target E
+			return errors.As(err, &target)
+		},
+		mapping: mapping,
+	})
+}
+
+// lookupError returns the first registered mapping matching err.
+func (c *Client) lookupError(err error) (ErrorMapping, bool) {
+	for _, m := range c.errors {
+		if m.match(err) {
+			return m.mapping, true
+		}
+	}
+	return ErrorMapping{}, false
+}
+
+// errorFallback returns the mapping of unregistered errors with defaults applied.
+func (c *Client) errorFallback() ErrorMapping {
+	fallback := c.ErrorFallback
+	if fallback.Code == "" {
+		fallback.Code = "SERVER_ERROR"
+	}
+	if fallback.Message == "" {
+		fallback.Message = "Internal server error"
+	}
+	if fallback.Status == 0 {
+		if _, aliased := c.CodeAliases[fallback.Code]; !aliased {
+			fallback.Status = http.StatusInternalServerError
+		}
+	}
+	return fallback
+}
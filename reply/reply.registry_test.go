@@ -0,0 +1,124 @@
+package reply_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+// validationError is a domain error type matched by RegisterErrorType.
+type validationError struct{ field string }
+
+func (e *validationError) Error() string { return e.field + " is invalid" }
+
+func TestReplyErr(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    reply.Client
+		err       error
+		status    int
+		want      reply.ErrorPayload
+		wantDebug any
+	}{
+		{
+			name:   "Sentinel",
+			err:    sql.ErrNoRows,
+			status: http.StatusNotFound,
+			want:   reply.ErrorPayload{Code: "NOT_FOUND", Message: "Resource not found"},
+		},
+		{
+			name:   "WrappedSentinel",
+			err:    fmt.Errorf("load user: %w", sql.ErrNoRows),
+			status: http.StatusNotFound,
+			want:   reply.ErrorPayload{Code: "NOT_FOUND", Message: "Resource not found"},
+		},
+		{
+			name:   "AliasedStatus",
+			err:    context.DeadlineExceeded,
+			status: http.StatusGatewayTimeout,
+			want:   reply.ErrorPayload{Code: "TIMEOUT", Message: "context deadline exceeded"},
+		},
+		{
+			name:   "ErrorType",
+			err:    fmt.Errorf("create user: %w", &validationError{field: "email"}),
+			status: http.StatusBadRequest,
+			want:   reply.ErrorPayload{Code: "BAD_REQUEST", Message: "create user: email is invalid", Fields: reply.FieldsError{"email": "invalid"}},
+		},
+		{
+			name:   "ErrorPayload",
+			err:    reply.Conflict("Email taken"),
+			status: http.StatusConflict,
+			want:   reply.ErrorPayload{Code: "CONFLICT", Message: "Email taken"},
+		},
+		{
+			name:   "WrappedErrorPayload",
+			err:    fmt.Errorf("create user: %w", reply.ErrorPayload{Code: "CONFLICT", Message: "Email taken", Status: http.StatusConflict}),
+			status: http.StatusConflict,
+			want:   reply.ErrorPayload{Code: "CONFLICT", Message: "Email taken"},
+		},
+		{
+			name:   "FallbackHidesMessage",
+			err:    errors.New("dial tcp: connection refused"),
+			status: http.StatusInternalServerError,
+			want:   reply.ErrorPayload{Code: "SERVER_ERROR", Message: "Internal server error"},
+		},
+		{
+			name:      "FallbackDebug",
+			client:    reply.Client{DebugMode: true},
+			err:       errors.New("dial tcp: connection refused"),
+			status:    http.StatusInternalServerError,
+			want:      reply.ErrorPayload{Code: "SERVER_ERROR", Message: "Internal server error"},
+			wantDebug: "dial tcp: connection refused",
+		},
+		{
+			name:   "CustomFallback",
+			client: reply.Client{ErrorFallback: reply.ErrorMapping{Code: "UNAVAILABLE", Message: "Try again later", Status: http.StatusServiceUnavailable}},
+			err:    errors.New("dial tcp: connection refused"),
+			status: http.StatusServiceUnavailable,
+			want:   reply.ErrorPayload{Code: "UNAVAILABLE", Message: "Try again later"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.client.CodeAliases = reply.CodeAliases{"TIMEOUT": http.StatusGatewayTimeout}
+			client := reply.NewClient(tt.client)
+			client.RegisterError(sql.ErrNoRows, reply.ErrorMapping{Code: "NOT_FOUND", Message: "Resource not found", Status: http.StatusNotFound})
+			client.RegisterError(context.DeadlineExceeded, reply.ErrorMapping{Code: "TIMEOUT"})
+			reply.RegisterErrorType[*validationError](client, reply.ErrorMapping{
+				Code:    "BAD_REQUEST",
+				Status:  http.StatusBadRequest,
+				Options: []reply.ErrorOption{reply.WithFields(reply.FieldsError{"email": "invalid"})},
+			})
+
+			rp, rec := replytest.New(client, nil)
+			if err := rp.Err(tt.err).Fail(); err != nil {
+				t.Fatalf("Fail: %v", err)
+			}
+
+			rec.AssertStatus(t, tt.status).AssertErrorCode(t, tt.want.Code)
+			var got reply.ErrorPayload
+			rec.DecodeData(t, &got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("payload = %+v, want %+v", got, tt.want)
+			}
+			if debug := rec.Envelope(t).Meta.Debug; !reflect.DeepEqual(debug, tt.wantDebug) {
+				t.Errorf("debug = %v, want %v", debug, tt.wantDebug)
+			}
+		})
+	}
+}
+
+func TestReplyErrNil(t *testing.T) {
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+	rp.Success("ok").Err(nil).Ok()
+
+	rec.AssertStatus(t, http.StatusOK).AssertSuccess(t)
+}
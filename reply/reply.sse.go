@@ -0,0 +1,144 @@
+package reply
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chesta132/goreply/adapter"
+)
+
+// EventStream writes server-sent events to the response.
+// It is safe for concurrent use. Writes fail once the client disconnects,
+// which also cancels Context.
+type EventStream struct {
+	mu          sync.Mutex
+	w           adapter.StreamWriter
+	enc         Encoder
+	envelope    bool
+	lastEventID string
+	ctx         context.Context
+	cancel      context.CancelFunc
+	err         error
+}
+
+// sseLineBreaks strips line breaks, which would end an event field early.
+var sseLineBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// Send writes an event with the data encoded by the client JSON encoder.
+// event and id are optional, an empty event is dispatched as "message" by browsers.
+// Data is wrapped in ReplyEnvelope if SSEOptions.Envelope is set.
+//
+// Example:
+//
+//	s.Send("price", "42", Price{Symbol: "GO", Value: 1.25})
+//	// event: price
+//	// id: 42
+//	// data: {"symbol":"GO","value":1.25}
+func (s *EventStream) Send(event, id string, data any) error {
+	if s.envelope {
+		envelope := ReplyEnvelope{Meta: Meta{Status: "SUCCESS", Timestamp: time.Now().Unix()}, Data: data}
+		switch data.(type) {
+		case ErrorPayload, *ErrorPayload:
+			envelope.Meta.Status = "ERROR"
+		}
+		data = envelope
+	}
+
+	var body bytes.Buffer
+	if err := s.enc.Encode(&body, data); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", sseLineBreaks.Replace(event))
+	}
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", sseLineBreaks.Replace(id))
+	}
+	for line := range strings.Lines(strings.TrimRight(body.String(), "\n")) {
+		fmt.Fprintf(&b, "data: %s\n", strings.TrimRight(line, "\r\n"))
+	}
+	b.WriteString("\n")
+	return s.write(b.String())
+}
+
+// Retry tells the client how long to wait before reconnecting.
+func (s *EventStream) Retry(d time.Duration) error {
+	return s.write(fmt.Sprintf("retry: %d\n\n", d.Milliseconds()))
+}
+
+// Comment writes a comment line, ignored by clients. Useful to keep the connection open.
+func (s *EventStream) Comment(text string) error {
+	return s.write(": " + sseLineBreaks.Replace(text) + "\n\n")
+}
+
+// LastEventID returns the Last-Event-ID request header sent by reconnecting clients.
+func (s *EventStream) LastEventID() string {
+	return s.lastEventID
+}
+
+// Context returns a context canceled when the client disconnects or the stream ends.
+//
+// Example:
+//
+//	for {
+//		select {
+//		case <-s.Context().Done():
+//			return nil
+//		case msg := <-messages:
+//			s.Send("message", "", msg)
+//		}
+//	}
+func (s *EventStream) Context() context.Context {
+	return s.ctx
+}
+
+// write writes and flushes raw event text. The first failure is kept
+// and returned by every later write, and cancels the stream context.
+func (s *EventStream) write(text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if _, err := s.w.Write([]byte(text)); err != nil {
+		s.fail(err)
+		return err
+	}
+	if err := s.w.Flush(); err != nil {
+		s.fail(err)
+		return err
+	}
+	return nil
+}
+
+// fail records a write error and stops the stream.
+func (s *EventStream) fail(err error) {
+	s.err = err
+	s.cancel()
+}
+
+// heartbeat writes a comment every interval until the stream context is done.
+func (s *EventStream) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if s.Comment("heartbeat") != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package reply
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+)
+
+// templateSet holds the parsed pages of a template file system.
+// Each page is parsed in its own clone of the shared layouts and partials,
+// so pages may define the same blocks without conflicts.
+type templateSet struct {
+	fsys  fs.FS
+	opts  TemplateOptions
+	mu    sync.RWMutex
+	pages map[string]*template.Template
+}
+
+// LoadTemplates parses the HTML templates of fsys for Render.
+// Files under the Layouts and Partials directories are shared by every page,
+// any other file with the template extension is a page.
+// Returns the first parse error, keeping previously loaded templates.
+//
+// Example:
+//
+//	//go:embed views
+//	var views embed.FS
+//
+//	sub, _ := fs.Sub(views, "views")
+//	err := Client.LoadTemplates(sub, reply.TemplateOptions{Layout: "layouts/main"})
+func (c *Client) LoadTemplates(fsys fs.FS, opts TemplateOptions) error {
+	if opts.Extension == "" {
+		opts.Extension = ".html"
+	}
+	if opts.Layouts == "" {
+		opts.Layouts = "layouts"
+	}
+	if opts.Partials == "" {
+		opts.Partials = "partials"
+	}
+
+	set := &templateSet{fsys: fsys, opts: opts}
+	if err := set.parse(); err != nil {
+		return err
+	}
+	c.templates = set
+	return nil
+}
+
+// parse parses every template file of the set, replacing its pages on success.
+func (s *templateSet) parse() error {
+	var shared, pages []string
+	err := fs.WalkDir(s.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(name) != s.opts.Extension {
+			return err
+		}
+		if inDir(name, s.opts.Layouts) || inDir(name, s.opts.Partials) {
+			shared = append(shared, name)
+		} else {
+			pages = append(pages, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	base := template.New("").Funcs(s.opts.Funcs)
+	for _, name := range shared {
+		if err := s.parseFile(base, name); err != nil {
+			return err
+		}
+	}
+
+	parsed := make(map[string]*template.Template, len(pages))
+	for _, name := range pages {
+		page, err := base.Clone()
+		if err != nil {
+			return err
+		}
+		if err := s.parseFile(page, name); err != nil {
+			return err
+		}
+		parsed[s.templateName(name)] = page
+	}
+
+	s.mu.Lock()
+	s.pages = parsed
+	s.mu.Unlock()
+	return nil
+}
+
+// parseFile parses a template file into t, named by its path without extension.
+func (s *templateSet) parseFile(t *template.Template, name string) error {
+	content, err := fs.ReadFile(s.fsys, name)
+	if err != nil {
+		return err
+	}
+	if _, err := t.New(s.templateName(name)).Parse(string(content)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// templateName returns the template name of a file, its path without extension.
+func (s *templateSet) templateName(name string) string {
+	return strings.TrimSuffix(name, s.opts.Extension)
+}
+
+// lookup returns the page template and the name to execute it with.
+// Templates are parsed again first if reloading is enabled.
+func (s *templateSet) lookup(name string) (*template.Template, string, error) {
+	if s.opts.Reload {
+		if err := s.parse(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	s.mu.RLock()
+	page, ok := s.pages[name]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	}
+	if s.opts.Layout != "" {
+		return page, s.opts.Layout, nil
+	}
+	return page, name, nil
+}
+
+// inDir reports whether the slash-separated name is inside dir.
+func inDir(name, dir string) bool {
+	return strings.HasPrefix(name, strings.Trim(dir, "/")+"/")
+}
@@ -1,7 +1,11 @@
 package reply
 
 import (
+	"encoding/xml"
+	"html/template"
 	"io"
+	"net/url"
+	"time"
 
 	"github.com/chesta132/goreply/adapter"
 )
@@ -12,12 +16,34 @@ type Transformer func(client *Reply) any
 // Finalizer defines a function to run before sending the response.
 type Finalizer func(client *Reply)
 
+// PanicHandler defines a function to report a recovered panic, e.g. to an error tracker.
+type PanicHandler func(rp *Reply, recovered any, stack []byte)
+
+// Encoder serializes payloads for a response format.
+// Register custom encoders with Client.RegisterEncoder.
+type Encoder interface {
+	ContentType() string             // Content-Type of encoded bodies, e.g. "application/json"
+	Encode(w io.Writer, v any) error // Writes the encoded value to w
+}
+
+// Compressor wraps a writer with a compressing writer for a content coding.
+// Writers implementing Flush() error are flushed while streaming.
+type Compressor func(w io.Writer) io.WriteCloser
+
 // CodeAliases maps error codes to HTTP status codes.
 type CodeAliases map[string]int
 
 // DefaultHeaders defines default headers to include in every response.
 type DefaultHeaders map[string]string
 
+// ErrorPages maps HTTP status codes to the templates rendering their HTML error pages.
+// Status 0 sets the template of any other status.
+//
+// Example:
+//
+//	ErrorPages{http.StatusNotFound: "errors/404", 0: "errors/default"}
+type ErrorPages map[int]string
+
 // PaginationType defines the pagination strategy.
 type PaginationType string
 
@@ -28,6 +54,38 @@ const (
 	PaginationOffset PaginationType = "offset"
 )
 
+// ETagMode defines how ETags are computed from encoded payloads.
+type ETagMode string
+
+const (
+	// ETagNone disables computed ETags.
+	ETagNone ETagMode = "none"
+	// ETagStrong computes strong ETags, e.g. "3f2a...".
+	ETagStrong ETagMode = "strong"
+	// ETagWeak computes weak ETags, e.g. W/"3f2a...".
+	ETagWeak ETagMode = "weak"
+)
+
+// Format defines a response format selectable by content negotiation.
+type Format string
+
+const (
+	// FormatJSON negotiates application/json.
+	FormatJSON Format = "json"
+	// FormatXML negotiates application/xml and text/xml.
+	FormatXML Format = "xml"
+	// FormatText negotiates text/plain. Only offered for string data.
+	FormatText Format = "text"
+	// FormatHTML negotiates text/html. Only offered for string data.
+	FormatHTML Format = "html"
+	// FormatMsgPack negotiates the media types of the registered MessagePack encoder.
+	FormatMsgPack Format = "msgpack"
+	// FormatCBOR negotiates the media types of the registered CBOR encoder.
+	FormatCBOR Format = "cbor"
+	// FormatYAML negotiates the media types of the registered YAML encoder.
+	FormatYAML Format = "yaml"
+)
+
 // Tokens holds authentication or session tokens.
 type Tokens map[string]string
 
@@ -43,6 +101,18 @@ type FieldsError map[string]string
 // ErrorOption defines function to build options in ErrorPayload.
 type ErrorOption func(*ErrorPayload)
 
+// ErrorMapping defines how an error is rendered by Reply.Err.
+//
+// Example:
+//
+//	ErrorMapping{Code: "NOT_FOUND", Message: "Resource not found", Status: 404}
+type ErrorMapping struct {
+	Code    string        // Machine-readable error code
+	Message string        // Human-readable message. Default: the error message
+	Status  int           // HTTP status code. Default: from CodeAliases or 500
+	Options []ErrorOption // Optional values of the error payload
+}
+
 // Pagination holds pagination metadata, embedded in Meta when needed.
 //
 // Example:
@@ -53,6 +123,52 @@ type Pagination struct {
 	HasNext bool `json:"hasNext" xml:"hasNext"`                 // True if more results exist
 	Current int  `json:"current" xml:"current"`                 // Current page/offset
 	Total   int  `json:"total,omitempty" xml:"total,omitempty"` // Total data, available if use total paginate
+
+	// Available with Client.PaginationDetails
+	Prev       *int             `json:"prev,omitempty" xml:"prev,omitempty"`             // Previous page/offset, nil on the first one
+	TotalPages int              `json:"totalPages,omitempty" xml:"totalPages,omitempty"` // Total pages, available if use total paginate
+	Limit      int              `json:"limit,omitempty" xml:"limit,omitempty"`           // Page size
+	Links      *PaginationLinks `json:"links,omitempty" xml:"links,omitempty"`           // Navigation URLs
+}
+
+// PageParams holds the pagination parameters of a request, read by Reply.PageParams.
+//
+// Example:
+//
+//	// PaginationType: "page", GET /users?page=3&limit=10
+//	PageParams{Limit: 10, Current: 3, Offset: 20}
+type PageParams struct {
+	Limit   int    // Page size, capped to Client.MaxPageSize
+	Current int    // Page or offset by Client.PaginationType, as taken by PaginateTotal and PaginateCursor
+	Offset  int    // Offset of the first element, for both pagination types
+	Cursor  string // Raw keyset cursor, decoded with DecodeCursor
+}
+
+// PaginationLinks holds navigation URLs of a paginated request, relative to the host.
+// Links not applicable to the current page are empty.
+//
+// Example:
+//
+//	PaginationLinks{First: "/users?page=1", Next: "/users?page=3", Prev: "/users?page=1", Last: "/users?page=5"}
+type PaginationLinks struct {
+	First string `json:"first,omitempty" xml:"first,omitempty"` // URL of the first page
+	Prev  string `json:"prev,omitempty" xml:"prev,omitempty"`   // URL of the previous page
+	Next  string `json:"next,omitempty" xml:"next,omitempty"`   // URL of the next page
+	Last  string `json:"last,omitempty" xml:"last,omitempty"`   // URL of the last page, available if use total paginate
+}
+
+// KeysetPagination holds keyset pagination metadata, embedded in Meta by PaginateKeyset.
+//
+// Example:
+//
+//	KeysetPagination{HasNext: true, NextCursor: "eyJpZCI6NDJ9"}
+type KeysetPagination struct {
+	HasNext    bool   `json:"hasNext" xml:"hasNext"`                           // True if more results exist
+	NextCursor string `json:"nextCursor,omitempty" xml:"nextCursor,omitempty"` // Opaque cursor of the next page
+
+	// Available with Client.PaginationDetails
+	Limit int              `json:"limit,omitempty" xml:"limit,omitempty"` // Page size
+	Links *PaginationLinks `json:"links,omitempty" xml:"links,omitempty"` // Navigation URLs
 }
 
 // Meta contains reply metadata.
@@ -61,12 +177,13 @@ type Pagination struct {
 //
 //	Meta{Status: "SUCCESS", Info: "Fetched 10 items"}
 type Meta struct {
-	Status     string      `json:"status" xml:"status"`                               // "SUCCESS" or "ERROR"
-	Info       string      `json:"information,omitempty" xml:"information,omitempty"` // Optional info message
-	Pagination *Pagination `json:"pagination,omitempty" xml:"pagination,omitempty"`   // Pagination info if applicable
-	Timestamp  int64       `json:"timestamp" xml:"timestamp"`                         // TImestamp of replied time
-	Tokens     Tokens      `json:"tokens,omitempty" xml:"tokens,omitempty"`           // Optional tokens (e.g. auth)
-	Debug      any         `json:"debug,omitempty,omitzero" xml:"debug,omitempty"`    // Optional debug info
+	Status     string            `json:"status" xml:"status"`                               // "SUCCESS" or "ERROR"
+	Info       string            `json:"information,omitempty" xml:"information,omitempty"` // Optional info message
+	Pagination *Pagination       `json:"pagination,omitempty" xml:"pagination,omitempty"`   // Pagination info if applicable
+	Keyset     *KeysetPagination `json:"keyset,omitempty" xml:"keyset,omitempty"`           // Keyset pagination info if applicable
+	Timestamp  int64             `json:"timestamp" xml:"timestamp"`                         // TImestamp of replied time
+	Tokens     Tokens            `json:"tokens,omitempty" xml:"tokens,omitempty"`           // Optional tokens (e.g. auth)
+	Debug      any               `json:"debug,omitempty,omitzero" xml:"debug,omitempty"`    // Optional debug info
 }
 
 // ReplyEnvelope is the standard API response envelope.
@@ -80,6 +197,7 @@ type ReplyEnvelope struct {
 }
 
 // ErrorPayload defines the error response body.
+// It implements error, so it can be returned from any layer and rendered with Reply.Err.
 //
 // Example:
 //
@@ -89,6 +207,26 @@ type ErrorPayload struct {
 	Message string      `json:"message" xml:"message"`                     // Human-readable message
 	Details string      `json:"details,omitempty" xml:"details,omitempty"` // Optional debug details
 	Fields  FieldsError `json:"fields,omitempty" xml:"fields,omitempty"`   // Fields causing the error (if any)
+	Status  int         `json:"-" xml:"-"`                                 // HTTP status code, overrides CodeAliases (if any)
+
+	cause error // Wrapped error, returned by Unwrap
+}
+
+// Problem is an RFC 9457 problem details document built from an ErrorPayload.
+//
+// Example:
+//
+//	Problem{Type: "https://example.com/problems/not-found", Title: "User not found", Status: 404}
+type Problem struct {
+	XMLName  xml.Name    `json:"-" xml:"urn:ietf:rfc:7807 problem"`
+	Type     string      `json:"type" xml:"type"`                             // Problem type URI, built from the error code
+	Title    string      `json:"title" xml:"title"`                           // Human-readable summary, from the error message, or the status text for "about:blank"
+	Status   int         `json:"status" xml:"status"`                         // HTTP status code
+	Detail   string      `json:"detail,omitempty" xml:"detail,omitempty"`     // Occurrence explanation, from the error details, or the message for "about:blank"
+	Instance string      `json:"instance,omitempty" xml:"instance,omitempty"` // Request path of the occurrence
+	Code     string      `json:"code,omitempty" xml:"code,omitempty"`         // Extension: machine-readable error code
+	Errors   FieldsError `json:"errors,omitempty" xml:"errors,omitempty"`     // Extension: fields causing the error
+	Debug    any         `json:"debug,omitempty" xml:"debug,omitempty"`       // Extension: debug info, only in DebugMode. Encoded as JSON text in XML
 }
 
 // Reply is the main HTTP response helper with chained methods.
@@ -100,19 +238,46 @@ type Reply struct {
 	c      *Client         // Client config
 	sent   bool            // True after response is sent
 	defers []func()        // Functions to execute before sending
+
+	etag         string    // Caller-supplied ETag
+	etagMode     ETagMode  // Per-reply computed ETag mode, overrides Client.ETag
+	lastModified time.Time // Caller-supplied Last-Modified
 }
 
 // Client holds global config for Reply instances.
 type Client struct {
-	Finalizer      Finalizer      // Runs before sending
-	Transformer    Transformer    // Transforms payload
-	CodeAliases    CodeAliases    // Maps error codes to HTTP status
-	DefaultHeaders DefaultHeaders // Default response headers
-	PaginationType PaginationType // "page" or "offset". Default: "offset"
-	DebugMode      bool           // If true, includes debug info in responses. Default: false
+	Finalizer         Finalizer      // Runs before sending
+	Transformer       Transformer    // Transforms payload
+	CodeAliases       CodeAliases    // Maps error codes to HTTP status
+	DefaultHeaders    DefaultHeaders // Default response headers
+	PaginationType    PaginationType // "page" or "offset". Default: "offset"
+	PaginationLinks   bool           // If true, pagination sets RFC 8288 Link headers (first, prev, next, last). Default: false
+	PaginationDetails bool           // If true, pagination meta includes prev, totalPages, limit and links. Default: false
+	PaginationParam   string         // Query parameter of the page or offset in pagination links. Default: "page" or "offset"
+	CursorParam       string         // Query parameter of keyset cursors. Default: "cursor"
+	CursorSecret      []byte         // If set, keyset cursors are signed with HMAC-SHA256 and verified on decode. Default: nil
+	LimitParam        string         // Query parameter of the page size read by PageParams. Default: "limit"
+	DefaultPageSize   int            // Page size of PageParams when the limit is omitted. Default: 20
+	MaxPageSize       int            // Largest page size of PageParams, bigger limits are capped. Default: 100
+	DebugMode         bool           // If true, includes debug info in responses. Default: false
+	DefaultFormat     Format         // Format used when Accept is empty or a wildcard. Default: "json"
+	ProblemDetails    bool           // If true, Fail senders render RFC 9457 problem details. Default: false
+	ProblemTypeURI    string         // Base URI of problem types, joined with the kebab-cased error code. Default: "about:blank"
+	ErrorFallback     ErrorMapping   // Mapping of unregistered errors in Err. Default: "SERVER_ERROR", 500
+	PanicCode         string         // Error code replied by Recover. Default: "SERVER_ERROR"
+	OnPanic           PanicHandler   // Reports panics caught by Recover
+	ETag              ETagMode       // ETag computed for 2xx encoded replies, "strong" or "weak". Default: "" (none)
+	Compression       bool           // If true, bodies are compressed as negotiated from Accept-Encoding. Default: false
+	CompressMin       int            // Smallest body size compressed, streams are always compressed. Default: 1024
+	ErrorPages        ErrorPages     // Templates of HTML error pages by status code. Default: built-in page
 
-	presets     map[string]Preset     // Registered value presets func map
-	sendPresets map[string]SendPreset // Registered sender presets func map
+	presets     map[string]Preset        // Registered value presets func map
+	sendPresets map[string]SendPreset    // Registered sender presets func map
+	errors      []errorMatcher           // Registered error mappings, in registration order
+	encoders    map[Format]formatEncoder // Registered encoders by format
+	formats     []Format                 // Registered encoder formats, in registration order
+	compressors []namedCompressor        // Registered compressors, in preference order
+	templates   *templateSet             // Loaded HTML templates
 }
 
 // Stream enables streaming responses (files, SSE, etc.).
@@ -124,3 +289,105 @@ type Stream struct {
 	Data        io.Reader // Stream source
 	ContentType string    // MIME type of the stream
 }
+
+// CSVOptions configures CSV and TSV exports.
+//
+// Example:
+//
+//	CSVOptions{Filename: "users.csv", Columns: []string{"id", "name"}}
+type CSVOptions struct {
+	Filename string   // Attachment filename. Default: "export.csv" or "export.tsv"
+	Columns  []string // Columns to export, in order. Default: all struct fields or sorted map keys
+	NoHeader bool     // If true, the header row is omitted. Default: false
+}
+
+// MetaPlacement defines where a streamed JSON envelope writes its meta.
+type MetaPlacement string
+
+const (
+	// MetaHead writes meta before data, like the standard envelope.
+	MetaHead MetaPlacement = "head"
+	// MetaTail writes meta after data, with pagination total set to the streamed count.
+	MetaTail MetaPlacement = "tail"
+	// MetaTrailer writes meta before data and the final meta in the X-Reply-Meta HTTP trailer.
+	MetaTrailer MetaPlacement = "trailer"
+)
+
+// JSONStreamOptions configures streamed JSON envelopes.
+//
+// Example:
+//
+//	JSONStreamOptions{Meta: reply.MetaTail, FlushEvery: 500}
+type JSONStreamOptions struct {
+	Meta       MetaPlacement // Where meta is written. Default: "head"
+	FlushEvery int           // Elements written between flushes. Default: 100
+}
+
+// SSEOptions configures server-sent event streams.
+//
+// Example:
+//
+//	SSEOptions{Heartbeat: 15 * time.Second, Retry: 3 * time.Second, Envelope: true}
+type SSEOptions struct {
+	Heartbeat time.Duration // Interval of heartbeat comments keeping the connection open. Default: 0 (disabled)
+	Retry     time.Duration // Reconnection delay hint sent on open. Default: 0 (not sent)
+	Envelope  bool          // If true, event data is wrapped in ReplyEnvelope. Default: false
+}
+
+// ContentOptions configures File and Content responses.
+//
+// Example:
+//
+//	ContentOptions{Attachment: true, Filename: "report.pdf"}
+type ContentOptions struct {
+	Attachment  bool   // If true, Content-Disposition is attachment instead of inline. Default: false
+	Filename    string // Filename of Content-Disposition. Default: base name of the content
+	ContentType string // Content-Type of the content. Default: from the name extension, then sniffed
+}
+
+// TemplateOptions configures HTML templates loaded by Client.LoadTemplates.
+// Templates are named by their path without extension, e.g. "users/show" or "layouts/main".
+//
+// Example:
+//
+//	TemplateOptions{Layout: "layouts/main", Funcs: template.FuncMap{"upper": strings.ToUpper}, Reload: debug}
+type TemplateOptions struct {
+	Extension string           // Extension of template files. Default: ".html"
+	Layouts   string           // Directory of layouts, shared by every page. Default: "layouts"
+	Partials  string           // Directory of partials, shared by every page. Default: "partials"
+	Layout    string           // Layout executed for pages, which fills its blocks. Default: "" (page executed directly)
+	Funcs     template.FuncMap // Functions available in every template
+	Reload    bool             // If true, templates are parsed again on each render, for development. Default: false
+}
+
+// RenderData is the value templates are executed with.
+//
+// Example:
+//
+//	<h1>{{.Data.Name}}</h1> <a href="{{.Request.Path}}?page={{.Meta.Pagination.Next}}">Next</a>
+type RenderData struct {
+	Data    any         // Reply data
+	Meta    Meta        // Reply meta
+	Request RequestInfo // Rendered request
+}
+
+// ErrorPage is the data of HTML error pages, available as .Data in error page templates.
+//
+// Example:
+//
+//	<h1>{{.Data.Status}} {{.Data.Title}}</h1> <p>{{.Data.Message}}</p>
+type ErrorPage struct {
+	Status  int         // HTTP status code
+	Title   string      // HTTP status text, e.g. "Not Found"
+	Code    string      // Machine-readable error code
+	Message string      // Human-readable message. Default: the status text
+	Details string      // Debug details, only in DebugMode
+	Fields  FieldsError // Fields causing the error (if any)
+}
+
+// RequestInfo describes the request a template is rendered for.
+type RequestInfo struct {
+	Method string     // Request method
+	Path   string     // Request URL path
+	Query  url.Values // Request URL query values
+}
@@ -0,0 +1,118 @@
+package replytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+)
+
+// update rewrites golden files with the recorded responses instead of comparing them.
+var update = flag.Bool("replytest.update", false, "update replytest golden files")
+
+// AssertStatus asserts the recorded status code.
+func (r *Recorder) AssertStatus(t testing.TB, code int) *Recorder {
+	t.Helper()
+	if r.Status() != code {
+		t.Errorf("replytest: status = %d, want %d\nbody: %s", r.Status(), code, r.Body())
+	}
+	return r
+}
+
+// AssertSuccess asserts the recorded envelope has status "SUCCESS".
+func (r *Recorder) AssertSuccess(t testing.TB) *Recorder {
+	t.Helper()
+	if status := r.Envelope(t).Meta.Status; status != "SUCCESS" {
+		t.Errorf("replytest: meta.status = %q, want SUCCESS\nbody: %s", status, r.Body())
+	}
+	return r
+}
+
+// AssertErrorCode asserts the recorded envelope is an error with the given code.
+func (r *Recorder) AssertErrorCode(t testing.TB, code string) *Recorder {
+	t.Helper()
+	if status := r.Envelope(t).Meta.Status; status != "ERROR" {
+		t.Errorf("replytest: meta.status = %q, want ERROR\nbody: %s", status, r.Body())
+		return r
+	}
+	var payload reply.ErrorPayload
+	r.DecodeData(t, &payload)
+	if payload.Code != code {
+		t.Errorf("replytest: error code = %q, want %q\nbody: %s", payload.Code, code, r.Body())
+	}
+	return r
+}
+
+// AssertPagination asserts the pagination of the recorded envelope.
+func (r *Recorder) AssertPagination(t testing.TB, want reply.Pagination) *Recorder {
+	t.Helper()
+	got := r.Envelope(t).Meta.Pagination
+	if got == nil {
+		t.Errorf("replytest: meta.pagination is missing, want %+v", want)
+		return r
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("replytest: meta.pagination = %+v, want %+v", *got, want)
+	}
+	return r
+}
+
+// AssertHeader asserts the first value of a recorded response header.
+func (r *Recorder) AssertHeader(t testing.TB, key, value string) *Recorder {
+	t.Helper()
+	if got := r.Header().Get(key); got != value {
+		t.Errorf("replytest: header %s = %q, want %q", key, got, value)
+	}
+	return r
+}
+
+// AssertGolden compares the recorded body with the golden file at path.
+// JSON bodies are compared indented, without meta.timestamp so snapshots stay stable.
+// Run tests with -replytest.update to write the golden files.
+func (r *Recorder) AssertGolden(t testing.TB, path string) *Recorder {
+	t.Helper()
+	got := normalize(r.Body())
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("replytest: create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("replytest: write golden file: %v", err)
+		}
+		return r
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("replytest: read golden file: %v (run with -replytest.update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("replytest: body does not match %s\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+	return r
+}
+
+// normalize indents JSON bodies and removes meta.timestamp.
+// Non-JSON bodies are returned as is.
+func normalize(body []byte) []byte {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	if m, ok := v.(map[string]any); ok {
+		if meta, ok := m["meta"].(map[string]any); ok {
+			delete(meta, "timestamp")
+		}
+	}
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return body
+	}
+	return append(out, '\n')
+}
@@ -0,0 +1,120 @@
+package replytest
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+)
+
+// fakeTB records assertion failures instead of failing the running test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// runFake runs fn with a fakeTB on its own goroutine, so Fatalf can stop it.
+func runFake(fn func(tb testing.TB)) *fakeTB {
+	tb := &fakeTB{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(tb)
+	}()
+	<-done
+	return tb
+}
+
+func TestAssertions(t *testing.T) {
+	client := reply.NewClient(reply.Client{})
+	found := func(rp *reply.Reply) {
+		rp.Success(user{ID: 42, Name: "Jane"}).PaginateTotal(10, 0, 25).OkJSON()
+	}
+	missing := func(rp *reply.Reply) { rp.Error("NOT_FOUND", "User not found").FailJSON() }
+	text := func(rp *reply.Reply) { rp.Success("plain").OkText() }
+
+	tests := []struct {
+		name     string
+		send     func(rp *reply.Reply)
+		assert   func(rec *Recorder, tb testing.TB)
+		wantFail bool
+	}{
+		{"Status", found, func(rec *Recorder, tb testing.TB) { rec.AssertStatus(tb, http.StatusOK) }, false},
+		{"StatusMismatch", found, func(rec *Recorder, tb testing.TB) { rec.AssertStatus(tb, http.StatusCreated) }, true},
+		{"Success", found, func(rec *Recorder, tb testing.TB) { rec.AssertSuccess(tb) }, false},
+		{"SuccessOnError", missing, func(rec *Recorder, tb testing.TB) { rec.AssertSuccess(tb) }, true},
+		{"SuccessNotJSON", text, func(rec *Recorder, tb testing.TB) { rec.AssertSuccess(tb) }, true},
+		{"ErrorCode", missing, func(rec *Recorder, tb testing.TB) { rec.AssertErrorCode(tb, "NOT_FOUND") }, false},
+		{"ErrorCodeMismatch", missing, func(rec *Recorder, tb testing.TB) { rec.AssertErrorCode(tb, "CONFLICT") }, true},
+		{"ErrorCodeOnSuccess", found, func(rec *Recorder, tb testing.TB) { rec.AssertErrorCode(tb, "NOT_FOUND") }, true},
+		{"Pagination", found, func(rec *Recorder, tb testing.TB) {
+			rec.AssertPagination(tb, reply.Pagination{Next: 10, HasNext: true, Total: 25})
+		}, false},
+		{"PaginationMismatch", found, func(rec *Recorder, tb testing.TB) {
+			rec.AssertPagination(tb, reply.Pagination{Next: 10, Total: 25})
+		}, true},
+		{"PaginationMissing", missing, func(rec *Recorder, tb testing.TB) { rec.AssertPagination(tb, reply.Pagination{}) }, true},
+		{"Header", found, func(rec *Recorder, tb testing.TB) {
+			rec.AssertHeader(tb, "Content-Type", "application/json; charset=utf-8")
+		}, false},
+		{"HeaderMismatch", found, func(rec *Recorder, tb testing.TB) { rec.AssertHeader(tb, "Content-Type", "text/plain") }, true},
+		{"Golden", found, func(rec *Recorder, tb testing.TB) { rec.AssertGolden(tb, "testdata/found.golden") }, false},
+		{"GoldenText", text, func(rec *Recorder, tb testing.TB) { rec.AssertGolden(tb, "testdata/text.golden") }, false},
+		{"GoldenMismatch", missing, func(rec *Recorder, tb testing.TB) { rec.AssertGolden(tb, "testdata/found.golden") }, true},
+		{"GoldenMissing", found, func(rec *Recorder, tb testing.TB) { rec.AssertGolden(tb, "testdata/missing.golden") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := New(client, nil)
+			tt.send(rp)
+			tb := runFake(func(tb testing.TB) { tt.assert(rec, tb) })
+			if tb.failed != tt.wantFail {
+				t.Errorf("failed = %v, want %v (message: %q)", tb.failed, tt.wantFail, tb.msg)
+			}
+		})
+	}
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	if *update {
+		t.Skip("golden files are being updated")
+	}
+	path := filepath.Join(t.TempDir(), "nested", "found.golden")
+	rp, rec := New(reply.NewClient(reply.Client{}), nil)
+	rp.Success(user{ID: 42, Name: "Jane"}).PaginateTotal(10, 0, 25).OkJSON()
+
+	*update = true
+	rec.AssertGolden(t, path)
+	*update = false
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+	want, err := os.ReadFile("testdata/found.golden")
+	if err != nil {
+		t.Fatalf("read expected golden file: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("golden file = %s, want %s", got, want)
+	}
+	rec.AssertGolden(t, path)
+}
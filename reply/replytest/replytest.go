@@ -0,0 +1,102 @@
+// Package replytest provides utilities to unit test handlers built on reply
+// without running a web framework.
+//
+// Example:
+//
+//	func TestFindUser(t *testing.T) {
+//		rp, rec := replytest.New(Client, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+//		FindUser(rp, "42")
+//
+//		rec.AssertStatus(t, http.StatusNotFound).
+//			AssertErrorCode(t, "NOT_FOUND").
+//			AssertGolden(t, "testdata/find_user_not_found.golden")
+//	}
+package replytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/adapter"
+	nethttpadapter "github.com/chesta132/goreply/adapter/nethttp"
+	"github.com/chesta132/goreply/reply"
+)
+
+// Recorder is an in-memory adapter.Adapter that records the response.
+// It behaves like the net/http adapter writing to an httptest.ResponseRecorder.
+type Recorder struct {
+	adapter.Adapter
+
+	rec *httptest.ResponseRecorder
+}
+
+// NewRecorder creates a recorder adapter for req. A nil req defaults to GET /.
+//
+// Example:
+//
+//	rec := replytest.NewRecorder(nil)
+//	Client.New(rec).Success(user).OkJSON()
+func NewRecorder(req *http.Request) *Recorder {
+	if req == nil {
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+	}
+	rec := httptest.NewRecorder()
+	return &Recorder{Adapter: nethttpadapter.AdaptHttp(rec, req), rec: rec}
+}
+
+// New creates a reply of the client writing to a new recorder for req.
+//
+// Example:
+//
+//	rp, rec := replytest.New(Client, nil)
+func New(client *reply.Client, req *http.Request) (*reply.Reply, *Recorder) {
+	rec := NewRecorder(req)
+	return client.New(rec), rec
+}
+
+// Status returns the recorded status code.
+func (r *Recorder) Status() int {
+	return r.rec.Code
+}
+
+// Header returns the recorded response headers.
+func (r *Recorder) Header() http.Header {
+	return r.rec.Result().Header
+}
+
+// Body returns the recorded response body.
+func (r *Recorder) Body() []byte {
+	return r.rec.Body.Bytes()
+}
+
+// Envelope decodes the recorded JSON body into a reply envelope.
+// Data is decoded into generic JSON values, use DecodeData for typed data.
+func (r *Recorder) Envelope(t testing.TB) reply.ReplyEnvelope {
+	t.Helper()
+	var envelope reply.ReplyEnvelope
+	if err := json.Unmarshal(r.Body(), &envelope); err != nil {
+		t.Fatalf("replytest: decode envelope: %v\nbody: %s", err, r.Body())
+	}
+	return envelope
+}
+
+// DecodeData decodes the data of the recorded JSON envelope into v.
+//
+// Example:
+//
+//	var users []User
+//	rec.DecodeData(t, &users)
+func (r *Recorder) DecodeData(t testing.TB, v any) {
+	t.Helper()
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(r.Body(), &envelope); err != nil {
+		t.Fatalf("replytest: decode envelope: %v\nbody: %s", err, r.Body())
+	}
+	if err := json.Unmarshal(envelope.Data, v); err != nil {
+		t.Fatalf("replytest: decode data: %v\ndata: %s", err, envelope.Data)
+	}
+}
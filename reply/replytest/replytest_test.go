@@ -0,0 +1,52 @@
+package replytest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *http.Request
+		path string
+	}{
+		{"NilRequest", nil, "/"},
+		{"Request", httptest.NewRequest(http.MethodGet, "/users/42", nil), "/users/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := New(reply.NewClient(reply.Client{}), tt.req)
+			if got := rec.Path(); got != tt.path {
+				t.Errorf("path = %q, want %q", got, tt.path)
+			}
+			if err := rp.Success(user{ID: 42, Name: "Jane"}).Info("found").OkJSON(); err != nil {
+				t.Fatalf("OkJSON: %v", err)
+			}
+
+			if rec.Status() != http.StatusOK {
+				t.Errorf("status = %d, want 200", rec.Status())
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if envelope := rec.Envelope(t); envelope.Meta.Status != "SUCCESS" || envelope.Meta.Info != "found" {
+				t.Errorf("meta = %+v", envelope.Meta)
+			}
+			var got user
+			rec.DecodeData(t, &got)
+			if got != (user{ID: 42, Name: "Jane"}) {
+				t.Errorf("data = %+v", got)
+			}
+		})
+	}
+}
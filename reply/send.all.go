@@ -8,7 +8,7 @@ import "net/http"
 // 	rp.NoContent()
 func (r *Reply) NoContent() {
 	r.send(func() error {
-		r.a.Header().Del("Content-Type")
+		r.a.DelHeader("Content-Type")
 		r.a.SetStatus(http.StatusNoContent)
 		return nil
 	}, 1)
@@ -24,3 +24,14 @@ func (r *Reply) Redirect(statusCode int, url string) {
 		return nil
 	}, 1)
 }
+
+// Sent reports whether the response has already been sent.
+//
+// Example:
+//
+//	if !rp.Sent() {
+//		rp.Error("SERVER_ERROR", "Unhandled request").FailJSON()
+//	}
+func (r *Reply) Sent() bool {
+	return r.sent
+}
@@ -14,7 +14,9 @@ func (r *Reply) replyBinary(code int) error {
 			logError(errors.New("DATA TYPE IS NOT BYTE"), 2)
 			return r.a.BinarySender(code, []byte{})
 		}
-		return r.a.BinarySender(code, d)
+		return r.sendBody(code, "application/octet-stream", d, func() error {
+			return r.a.BinarySender(code, d)
+		})
 	}, 2)
 }
 
@@ -0,0 +1,36 @@
+package reply
+
+import "net/http"
+
+// ReplyCBOR sends a CBOR-encoded response with the specified status code.
+// Requires an encoder registered for FormatCBOR, e.g. with cborencoder.Register.
+// Returns ErrEncoderNotFound otherwise.
+//
+// Example:
+//
+//	cborencoder.Register(Client)
+//	rp.Success(Data{Msg: "ok"}).ReplyCBOR(http.StatusOK)
+func (r *Reply) ReplyCBOR(code int) error {
+	return r.replyEncoded(FormatCBOR, code)
+}
+
+// OkCBOR is a shortcut for ReplyCBOR with status 200 OK.
+func (r *Reply) OkCBOR() error {
+	return r.replyEncoded(FormatCBOR, http.StatusOK)
+}
+
+// CreatedCBOR sends status 201 Created with CBOR body.
+func (r *Reply) CreatedCBOR() error {
+	return r.replyEncoded(FormatCBOR, http.StatusCreated)
+}
+
+// FailCBOR sends a CBOR response with an error status.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500. Renders problem details if Client.ProblemDetails is enabled.
+func (r *Reply) FailCBOR(code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	if r.c.ProblemDetails {
+		return r.replyProblem(FormatCBOR, c)
+	}
+	return r.replyEncoded(FormatCBOR, c)
+}
@@ -0,0 +1,199 @@
+package reply
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// replyContent sends content with Range and conditional request support.
+// content is closed once sent if it implements io.Closer.
+func (r *Reply) replyContent(name string, modtime time.Time, content io.ReadSeeker, opts ContentOptions) error {
+	if r.sent {
+		closeContent(content)
+		return ErrAlreadySent
+	}
+
+	ctype := opts.ContentType
+	if ctype == "" {
+		ctype = mime.TypeByExtension(filepath.Ext(name))
+	}
+	if ctype == "" {
+		// sniff the first bytes, as net/http does
+		var buf [512]byte
+		n, _ := io.ReadFull(content, buf[:])
+		ctype = http.DetectContentType(buf[:n])
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			closeContent(content)
+			return err
+		}
+	}
+
+	size, err := content.Seek(0, io.SeekEnd)
+	if err == nil {
+		_, err = content.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		closeContent(content)
+		return err
+	}
+
+	if !r.lastModified.IsZero() {
+		modtime = r.lastModified
+	}
+	if r.preconditionFailed(r.etag, modtime) {
+		closeContent(content)
+		return r.Err(StatusError(http.StatusPreconditionFailed, "")).Fail()
+	}
+	if r.setValidators(r.etag, modtime) {
+		closeContent(content)
+		return r.send(r.replyNotModified, 3)
+	}
+
+	rangeHeader := r.a.RequestHeader("Range")
+	if !r.rangeAllowed(r.etag, modtime) {
+		rangeHeader = ""
+	}
+	ranges, err := parseRange(rangeHeader, size)
+	if rangeHeader != "" && err != nil {
+		closeContent(content)
+		r.a.SetHeader("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
+		return r.Err(StatusError(http.StatusRequestedRangeNotSatisfiable, "")).Fail()
+	}
+	// a client asking more bytes than the content has gets it whole
+	if sumRangesSize(ranges) > size {
+		ranges = nil
+	}
+
+	disposition := "inline"
+	if opts.Attachment {
+		disposition = "attachment"
+	}
+	filename := opts.Filename
+	if filename == "" && name != "" {
+		filename = filepath.Base(name)
+	}
+	params := map[string]string{}
+	if filename != "" {
+		params["filename"] = filename
+	}
+	r.a.SetHeader("Content-Disposition", mime.FormatMediaType(disposition, params))
+	r.a.SetHeader("Accept-Ranges", "bytes")
+
+	code := http.StatusOK
+	sendSize := size
+	var body io.Reader = content
+	closeBody := func() error { return closeContent(content) }
+
+	switch {
+	case len(ranges) == 1:
+		ra := ranges[0]
+		if _, err := content.Seek(ra.start, io.SeekStart); err != nil {
+			closeContent(content)
+			return r.Err(StatusError(http.StatusRequestedRangeNotSatisfiable, "")).Fail()
+		}
+		code = http.StatusPartialContent
+		sendSize = ra.length
+		body = io.LimitReader(content, ra.length)
+		r.a.SetHeader("Content-Range", ra.contentRange(size))
+	case len(ranges) > 1:
+		code = http.StatusPartialContent
+		sendSize = rangesMIMESize(ranges, ctype, size)
+
+		pr, pw := io.Pipe()
+		mw := multipart.NewWriter(pw)
+		partType := ctype
+		ctype = "multipart/byteranges; boundary=" + mw.Boundary()
+		body = pr
+		// the writer goroutine owns content, the adapter only stops it by closing the pipe
+		closeBody = func() error { return pr.Close() }
+		if r.a.Method() != http.MethodHead {
+			go func() {
+				defer closeContent(content)
+				for _, ra := range ranges {
+					part, err := mw.CreatePart(ra.mimeHeader(partType, size))
+					if err == nil {
+						_, err = content.Seek(ra.start, io.SeekStart)
+					}
+					if err == nil {
+						_, err = io.CopyN(part, content, ra.length)
+					}
+					if err != nil {
+						pw.CloseWithError(err)
+						return
+					}
+				}
+				pw.CloseWithError(mw.Close())
+			}()
+		}
+	}
+
+	r.a.SetHeader("Content-Length", strconv.FormatInt(sendSize, 10))
+	if r.a.Method() == http.MethodHead {
+		closeContent(content)
+		return r.send(func() error {
+			r.a.SetHeader("Content-Type", ctype)
+			r.a.SetStatus(code)
+			return nil
+		}, 3)
+	}
+	return r.send(func() error {
+		return r.a.StreamSender(code, ctype, contentReader{Reader: body, close: closeBody})
+	}, 3)
+}
+
+// Content sends content with support for Range (single and multipart/byteranges),
+// If-Range, If-Match, If-None-Match, If-Modified-Since and If-Unmodified-Since requests.
+// Entity tags are validated against the tag set with ETag.
+// Sets Content-Length, Accept-Ranges, Content-Disposition and, unless modtime is zero, Last-Modified.
+// Content-Type comes from the name extension unless given, or is sniffed from the content.
+// content is closed once sent if it implements io.Closer.
+//
+// Example:
+//
+//	rp.Content("clip.mp4", info.ModTime(), file) // Range: bytes=0-1023 -> 206 Partial Content
+func (r *Reply) Content(name string, modtime time.Time, content io.ReadSeeker, opts ...ContentOptions) error {
+	return r.replyContent(name, modtime, content, firstOption(opts))
+}
+
+// File sends the file at path like Content, using its name and modification time.
+// Replies 404 NOT_FOUND for missing files and directories, and 403 FORBIDDEN without permission.
+//
+// Example:
+//
+//	rp.File("./exports/report.pdf", reply.ContentOptions{Attachment: true})
+//	// Content-Disposition: attachment; filename=report.pdf
+func (r *Reply) File(path string, opts ...ContentOptions) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return r.fileError(err)
+	}
+	info, err := f.Stat()
+	if err == nil && info.IsDir() {
+		err = fs.ErrNotExist
+	}
+	if err != nil {
+		f.Close()
+		return r.fileError(err)
+	}
+	return r.replyContent(info.Name(), info.ModTime(), f, firstOption(opts))
+}
+
+// fileError replies the error of opening a file.
+func (r *Reply) fileError(err error) error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return r.Err(NotFound("File not found")).Fail()
+	case errors.Is(err, fs.ErrPermission):
+		return r.Err(Forbidden("File access denied")).Fail()
+	default:
+		return r.Err(err).Fail()
+	}
+}
@@ -0,0 +1,182 @@
+package reply_test
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+func TestReplyContent(t *testing.T) {
+	const content = "0123456789"
+	modtime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	before := modtime.Add(-time.Hour).Format(http.TimeFormat)
+	after := modtime.Add(time.Hour).Format(http.TimeFormat)
+
+	tests := []struct {
+		name         string
+		method       string
+		header       map[string]string
+		etag         string
+		opts         reply.ContentOptions
+		status       int
+		contentRange string
+		body         string
+	}{
+		{name: "Whole", status: http.StatusOK, body: content},
+		{name: "Head", method: http.MethodHead, status: http.StatusOK},
+		{name: "SingleRange", header: map[string]string{"Range": "bytes=2-5"}, status: http.StatusPartialContent, contentRange: "bytes 2-5/10", body: "2345"},
+		{name: "SuffixRange", header: map[string]string{"Range": "bytes=-3"}, status: http.StatusPartialContent, contentRange: "bytes 7-9/10", body: "789"},
+		{name: "OpenRange", header: map[string]string{"Range": "bytes=8-"}, status: http.StatusPartialContent, contentRange: "bytes 8-9/10", body: "89"},
+		{name: "Unsatisfiable", header: map[string]string{"Range": "bytes=20-30"}, status: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */10"},
+		{name: "Malformed", header: map[string]string{"Range": "bytes=5-2"}, status: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */10"},
+		{name: "IgnoredUnit", header: map[string]string{"Range": "items=0-1"}, status: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */10"},
+		{name: "IfRangeETagMatch", etag: "v1", header: map[string]string{"Range": "bytes=0-1", "If-Range": `"v1"`}, status: http.StatusPartialContent, contentRange: "bytes 0-1/10", body: "01"},
+		{name: "IfRangeETagChanged", etag: "v2", header: map[string]string{"Range": "bytes=0-1", "If-Range": `"v1"`}, status: http.StatusOK, body: content},
+		{name: "IfRangeDateMatch", header: map[string]string{"Range": "bytes=0-1", "If-Range": modtime.Format(http.TimeFormat)}, status: http.StatusPartialContent, contentRange: "bytes 0-1/10", body: "01"},
+		{name: "IfRangeDateChanged", header: map[string]string{"Range": "bytes=0-1", "If-Range": before}, status: http.StatusOK, body: content},
+		{name: "IfModifiedSinceNotModified", header: map[string]string{"If-Modified-Since": after}, status: http.StatusNotModified},
+		{name: "IfModifiedSinceModified", header: map[string]string{"If-Modified-Since": before}, status: http.StatusOK, body: content},
+		{name: "IfNoneMatch", etag: "v1", header: map[string]string{"If-None-Match": `"v1"`}, status: http.StatusNotModified},
+		{name: "IfUnmodifiedSinceFailed", header: map[string]string{"If-Unmodified-Since": before}, status: http.StatusPreconditionFailed},
+		{name: "IfUnmodifiedSincePassed", header: map[string]string{"If-Unmodified-Since": after}, status: http.StatusOK, body: content},
+		{name: "IfMatchFailed", etag: "v2", header: map[string]string{"If-Match": `"v1"`}, status: http.StatusPreconditionFailed},
+		{name: "IfMatchPassed", etag: "v1", header: map[string]string{"If-Match": `"v1"`}, status: http.StatusOK, body: content},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/files/digits.txt", nil)
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			rp, rec := replytest.New(reply.NewClient(reply.Client{}), req)
+			if tt.etag != "" {
+				rp.ETag(tt.etag)
+			}
+			if err := rp.Content("digits.txt", modtime, strings.NewReader(content), tt.opts); err != nil {
+				t.Fatalf("Content: %v", err)
+			}
+
+			rec.AssertStatus(t, tt.status).AssertHeader(t, "Content-Range", tt.contentRange)
+			if tt.status == http.StatusOK || tt.status == http.StatusPartialContent {
+				rec.AssertHeader(t, "Content-Type", "text/plain; charset=utf-8").
+					AssertHeader(t, "Accept-Ranges", "bytes").
+					AssertHeader(t, "Last-Modified", modtime.Format(http.TimeFormat))
+				if got := string(rec.Body()); got != tt.body {
+					t.Errorf("body = %q, want %q", got, tt.body)
+				}
+			}
+		})
+	}
+}
+
+func TestReplyContentMultipart(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Range", "bytes=0-1, 5-6")
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), req)
+	if err := rp.Content("digits.txt", time.Time{}, strings.NewReader("0123456789")); err != nil {
+		t.Fatalf("Content: %v", err)
+	}
+
+	rec.AssertStatus(t, http.StatusPartialContent)
+	mediaType, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if err != nil || mediaType != "multipart/byteranges" {
+		t.Fatalf("Content-Type = %q, want multipart/byteranges", rec.Header().Get("Content-Type"))
+	}
+	if got, want := rec.Header().Get("Content-Length"), len(rec.Body()); got != strconv.Itoa(want) {
+		t.Errorf("Content-Length = %s, want %d", got, want)
+	}
+
+	want := []struct{ contentRange, body string }{{"bytes 0-1/10", "01"}, {"bytes 5-6/10", "56"}}
+	mr := multipart.NewReader(strings.NewReader(string(rec.Body())), params["boundary"])
+	for i, w := range want {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("part %d: %v", i, err)
+		}
+		body, _ := io.ReadAll(part)
+		if part.Header.Get("Content-Range") != w.contentRange || string(body) != w.body {
+			t.Errorf("part %d = %q %q, want %q %q", i, part.Header.Get("Content-Range"), body, w.contentRange, w.body)
+		}
+		if part.Header.Get("Content-Type") != "text/plain; charset=utf-8" {
+			t.Errorf("part %d Content-Type = %q", i, part.Header.Get("Content-Type"))
+		}
+	}
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Errorf("extra part: %v", err)
+	}
+}
+
+func TestReplyContentOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		opts        reply.ContentOptions
+		contentType string
+		disposition string
+	}{
+		{"Extension", "report.json", "{}", reply.ContentOptions{}, "application/json", "inline; filename=report.json"},
+		{"Sniffed", "report", "<html><body></body></html>", reply.ContentOptions{}, "text/html; charset=utf-8", "inline; filename=report"},
+		{"Options", "report.json", "{}", reply.ContentOptions{Attachment: true, Filename: "r.txt", ContentType: "text/plain"}, "text/plain", "attachment; filename=r.txt"},
+		{"Unnamed", "", "{}", reply.ContentOptions{ContentType: "application/json"}, "application/json", "inline"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+			if err := rp.Content(tt.file, time.Time{}, strings.NewReader(tt.content), tt.opts); err != nil {
+				t.Fatalf("Content: %v", err)
+			}
+			rec.AssertStatus(t, http.StatusOK).
+				AssertHeader(t, "Content-Type", tt.contentType).
+				AssertHeader(t, "Content-Disposition", tt.disposition).
+				AssertHeader(t, "Last-Modified", "")
+		})
+	}
+}
+
+func TestReplyFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "report.txt"), []byte("report"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"Found", filepath.Join(dir, "report.txt"), http.StatusOK},
+		{"Missing", filepath.Join(dir, "missing.txt"), http.StatusNotFound},
+		{"Directory", dir, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+			if err := rp.File(tt.path); err != nil {
+				t.Fatalf("File: %v", err)
+			}
+			rec.AssertStatus(t, tt.status)
+			if tt.status == http.StatusOK && string(rec.Body()) != "report" {
+				t.Errorf("body = %q, want report", rec.Body())
+			}
+		})
+	}
+}
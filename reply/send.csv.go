@@ -0,0 +1,97 @@
+package reply
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// replyDelimited streams the data rows as delimited records with the specified status code.
+// Rows are written from a goroutine through a pipe, so the file is never buffered whole.
+// Pagination is sent in X-Pagination-* headers since there is no envelope.
+func (r *Reply) replyDelimited(code int, comma rune, contentType, filename string, opts CSVOptions) error {
+	rows, ok := tabularRows(r.m.Data)
+	if !ok {
+		logError(ErrNotTabular, 3)
+		return ErrNotTabular
+	}
+	if opts.Filename != "" {
+		filename = opts.Filename
+	}
+
+	return r.send(func() error {
+		r.a.SetHeader("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		if p := r.m.Meta.Pagination; p != nil {
+			r.a.SetHeader("X-Pagination-Current", strconv.Itoa(p.Current))
+			r.a.SetHeader("X-Pagination-Next", strconv.Itoa(p.Next))
+			r.a.SetHeader("X-Pagination-Has-Next", strconv.FormatBool(p.HasNext))
+			if p.Total > 0 {
+				r.a.SetHeader("X-Pagination-Total", strconv.Itoa(p.Total))
+			}
+		}
+		if k := r.m.Meta.Keyset; k != nil {
+			r.a.SetHeader("X-Pagination-Has-Next", strconv.FormatBool(k.HasNext))
+			if k.NextCursor != "" {
+				r.a.SetHeader("X-Pagination-Next-Cursor", k.NextCursor)
+			}
+		}
+
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(writeCSV(pw, rows, comma, opts))
+		}()
+
+		// unblock the writer if the adapter stopped reading
+		if err := r.streamBody(code, contentType, pr); err != nil {
+			pr.CloseWithError(err)
+			return err
+		}
+		return nil
+	}, 3)
+}
+
+// ReplyCSV streams a CSV attachment of the data with the specified status code.
+// Data must be a slice of structs or maps; headers come from csv or json struct tags.
+// Returns ErrNotTabular otherwise.
+//
+// Example:
+//
+//	rp.Success(users).PaginateTotal(limit, page, total).
+//		ReplyCSV(http.StatusOK, reply.CSVOptions{Filename: "users.csv", Columns: []string{"id", "name"}})
+//	// Content-Disposition: attachment; filename=users.csv
+//	// id,name
+//	// 1,Chesta
+func (r *Reply) ReplyCSV(code int, opts ...CSVOptions) error {
+	return r.replyDelimited(code, ',', "text/csv; charset=utf-8", "export.csv", firstOption(opts))
+}
+
+// OkCSV is a shortcut for ReplyCSV with status 200 OK.
+func (r *Reply) OkCSV(opts ...CSVOptions) error {
+	return r.replyDelimited(http.StatusOK, ',', "text/csv; charset=utf-8", "export.csv", firstOption(opts))
+}
+
+// ReplyTSV streams a tab-separated attachment of the data with the specified status code.
+// Data must be a slice of structs or maps; headers come from csv or json struct tags.
+// Returns ErrNotTabular otherwise.
+//
+// Example:
+//
+//	rp.Success(users).ReplyTSV(http.StatusOK, reply.CSVOptions{Filename: "users.tsv"})
+func (r *Reply) ReplyTSV(code int, opts ...CSVOptions) error {
+	return r.replyDelimited(code, '\t', "text/tab-separated-values; charset=utf-8", "export.tsv", firstOption(opts))
+}
+
+// OkTSV is a shortcut for ReplyTSV with status 200 OK.
+func (r *Reply) OkTSV(opts ...CSVOptions) error {
+	return r.replyDelimited(http.StatusOK, '\t', "text/tab-separated-values; charset=utf-8", "export.tsv", firstOption(opts))
+}
+
+// firstOption returns the first option, or the zero value if none given.
+func firstOption[T any](opts []T) T {
+	var opt T
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
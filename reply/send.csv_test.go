@@ -0,0 +1,155 @@
+package reply_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+type csvBase struct {
+	ID int `json:"id"`
+}
+
+type csvUser struct {
+	csvBase
+	Name     string    `csv:"full_name" json:"name"`
+	Email    string    `json:"email,omitempty"`
+	Password string    `json:"-"`
+	Tags     []string  `json:"tags"`
+	Joined   time.Time `json:"joined"`
+	Note     *string
+	internal string
+}
+
+func TestReplyCSV(t *testing.T) {
+	joined := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	note := "vip"
+	users := []csvUser{
+		{csvBase: csvBase{ID: 1}, Name: "Jane, Doe", Email: "jane@example.com", Password: "secret", Tags: []string{"a", "b"}, Joined: joined, Note: &note},
+		{csvBase: csvBase{ID: 2}, Name: "John", Joined: joined},
+	}
+
+	tests := []struct {
+		name        string
+		data        any
+		send        func(rp *reply.Reply) error
+		contentType string
+		disposition string
+		body        string
+	}{
+		{
+			name:        "Structs",
+			data:        users,
+			send:        func(rp *reply.Reply) error { return rp.OkCSV() },
+			contentType: "text/csv; charset=utf-8",
+			disposition: "attachment; filename=export.csv",
+			body: "id,full_name,email,tags,joined,Note\n" +
+				"1,\"Jane, Doe\",jane@example.com,\"[\"\"a\"\",\"\"b\"\"]\",2026-01-02T03:04:05Z,vip\n" +
+				"2,John,,,2026-01-02T03:04:05Z,\n",
+		},
+		{
+			name:        "StructPointers",
+			data:        []*csvUser{&users[1], nil},
+			send:        func(rp *reply.Reply) error { return rp.OkCSV(reply.CSVOptions{Columns: []string{"id", "full_name"}}) },
+			contentType: "text/csv; charset=utf-8",
+			disposition: "attachment; filename=export.csv",
+			body:        "id,full_name\n2,John\n,\n",
+		},
+		{
+			name:        "EmptyStructsHeader",
+			data:        []csvUser{},
+			send:        func(rp *reply.Reply) error { return rp.OkCSV() },
+			contentType: "text/csv; charset=utf-8",
+			disposition: "attachment; filename=export.csv",
+			body:        "id,full_name,email,tags,joined,Note\n",
+		},
+		{
+			name:        "Maps",
+			data:        []map[string]any{{"b": 2, "a": "x"}, {"a": "y", "c": true}},
+			send:        func(rp *reply.Reply) error { return rp.OkCSV() },
+			contentType: "text/csv; charset=utf-8",
+			disposition: "attachment; filename=export.csv",
+			body:        "a,b\nx,2\ny,\n",
+		},
+		{
+			name:        "EmptyMaps",
+			data:        []map[string]any{},
+			send:        func(rp *reply.Reply) error { return rp.OkCSV() },
+			contentType: "text/csv; charset=utf-8",
+			disposition: "attachment; filename=export.csv",
+		},
+		{
+			name: "ColumnsNoHeaderFilename",
+			data: users,
+			send: func(rp *reply.Reply) error {
+				return rp.ReplyCSV(http.StatusCreated, reply.CSVOptions{Filename: "users.csv", Columns: []string{"email", "id", "missing"}, NoHeader: true})
+			},
+			contentType: "text/csv; charset=utf-8",
+			disposition: "attachment; filename=users.csv",
+			body:        "jane@example.com,1,\n,2,\n",
+		},
+		{
+			name:        "TSV",
+			data:        users,
+			send:        func(rp *reply.Reply) error { return rp.OkTSV(reply.CSVOptions{Columns: []string{"id", "full_name"}}) },
+			contentType: "text/tab-separated-values; charset=utf-8",
+			disposition: "attachment; filename=export.tsv",
+			body:        "id\tfull_name\n1\tJane, Doe\n2\tJohn\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+			if err := tt.send(rp.Success(tt.data)); err != nil {
+				t.Fatalf("send: %v", err)
+			}
+
+			rec.AssertHeader(t, "Content-Type", tt.contentType).
+				AssertHeader(t, "Content-Disposition", tt.disposition)
+			if got := string(rec.Body()); got != tt.body {
+				t.Errorf("body = %q, want %q", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestReplyCSVPagination(t *testing.T) {
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+	if err := rp.Success([]csvBase{{ID: 1}, {ID: 2}}).PaginateTotal(2, 0, 5).OkCSV(); err != nil {
+		t.Fatalf("OkCSV: %v", err)
+	}
+
+	rec.AssertStatus(t, http.StatusOK).
+		AssertHeader(t, "X-Pagination-Current", "0").
+		AssertHeader(t, "X-Pagination-Next", "2").
+		AssertHeader(t, "X-Pagination-Has-Next", "true").
+		AssertHeader(t, "X-Pagination-Total", "5")
+}
+
+func TestReplyCSVNotTabular(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+	}{
+		{"Nil", nil},
+		{"Struct", csvBase{ID: 1}},
+		{"Strings", []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+			if err := rp.Success(tt.data).OkCSV(); !errors.Is(err, reply.ErrNotTabular) {
+				t.Errorf("err = %v, want ErrNotTabular", err)
+			}
+			if len(rec.Body()) != 0 {
+				t.Errorf("body = %s, want nothing sent", rec.Body())
+			}
+		})
+	}
+}
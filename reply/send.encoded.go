@@ -0,0 +1,71 @@
+package reply
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+)
+
+// replyEncoded encodes the Payload with the encoder of format
+// and sends the bytes with the specified status code.
+func (r *Reply) replyEncoded(format Format, code int) error {
+	enc, ok := r.c.encoder(format)
+	if !ok {
+		err := fmt.Errorf("%w: %q", ErrEncoderNotFound, format)
+		logError(err, 3)
+		return err
+	}
+	return r.send(func() error {
+		var body bytes.Buffer
+		// validators only describe successful representations
+		if code >= 200 && code < 300 {
+			etag, err := r.entityTag(format, enc, &body)
+			if err != nil {
+				return err
+			}
+			if r.setValidators(etag, r.lastModified) {
+				return r.replyNotModified()
+			}
+		}
+		if body.Len() == 0 {
+			if err := enc.Encode(&body, r.Payload); err != nil {
+				return err
+			}
+		}
+		return r.sendBody(code, enc.ContentType(), body.Bytes(), func() error {
+			return r.a.BytesSender(code, enc.ContentType(), body.Bytes())
+		})
+	}, 3)
+}
+
+// ReplyAs sends the response encoded with the encoder of format and the specified status code.
+// Returns ErrEncoderNotFound if no encoder is registered for format.
+//
+// Example:
+//
+//	Client.RegisterEncoder("msgpack", MsgPackEncoder{})
+//	rp.Success(user).ReplyAs("msgpack", http.StatusOK)
+func (r *Reply) ReplyAs(format Format, code int) error {
+	return r.replyEncoded(format, code)
+}
+
+// OkAs is a shortcut for ReplyAs with status 200 OK.
+func (r *Reply) OkAs(format Format) error {
+	return r.replyEncoded(format, http.StatusOK)
+}
+
+// CreatedAs sends status 201 Created encoded with the encoder of format.
+func (r *Reply) CreatedAs(format Format) error {
+	return r.replyEncoded(format, http.StatusCreated)
+}
+
+// FailAs sends a response encoded with the encoder of format and an error status.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500. Renders problem details if Client.ProblemDetails is enabled.
+func (r *Reply) FailAs(format Format, code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	if r.c.ProblemDetails {
+		return r.replyProblem(format, c)
+	}
+	return r.replyEncoded(format, c)
+}
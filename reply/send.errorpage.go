@@ -0,0 +1,23 @@
+package reply
+
+// replyErrorPage renders the error payload as an HTML error page with the specified status code.
+// Uses the Client.ErrorPages template of the status, or the built-in page.
+func (r *Reply) replyErrorPage(code int) error {
+	t, name := r.c.errorPageTemplate(code)
+	return r.send(func() error {
+		return r.sendTemplate(code, t, name, r.errorPage(code))
+	}, 2)
+}
+
+// FailHTML sends the error as an HTML error page.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500. Details and debug info are only shown in DebugMode.
+//
+// Example:
+//
+//	Client.ErrorPages = reply.ErrorPages{http.StatusNotFound: "errors/404"}
+//	rp.Error("NOT_FOUND", "User not found").FailHTML() // renders errors/404.html
+func (r *Reply) FailHTML(code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	return r.replyErrorPage(c)
+}
@@ -0,0 +1,163 @@
+package reply_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+const browserAccept = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+
+// errorViews returns templates of custom error pages.
+func errorViews() fstest.MapFS {
+	return fstest.MapFS{
+		"errors/404.html":     {Data: []byte(`<h1>Lost: {{.Data.Message}}</h1>`)},
+		"errors/default.html": {Data: []byte(`<h1>{{.Data.Status}} {{.Data.Title}}</h1>`)},
+	}
+}
+
+func TestReplyErrorPage(t *testing.T) {
+	notFound := func(rp *reply.Reply) error {
+		return rp.Error("NOT_FOUND", "User not found", reply.WithDetails("id 42")).Debug("trace").Fail()
+	}
+	conflict := func(rp *reply.Reply) error { return rp.Error("CONFLICT", "Email taken").Fail(http.StatusConflict) }
+
+	tests := []struct {
+		name        string
+		client      reply.Client
+		templates   bool
+		accept      string
+		fail        func(rp *reply.Reply) error
+		status      int
+		contentType string
+		contains    []string
+		excludes    []string
+	}{
+		{
+			name:        "BrowserBuiltin",
+			accept:      browserAccept,
+			fail:        notFound,
+			status:      http.StatusNotFound,
+			contentType: "text/html; charset=utf-8",
+			contains:    []string{"<title>404 Not Found</title>", "<p>User not found</p>", "<code>NOT_FOUND</code>"},
+			excludes:    []string{"id 42", "trace"},
+		},
+		{
+			name:        "BrowserBuiltinDebug",
+			client:      reply.Client{DebugMode: true},
+			accept:      browserAccept,
+			fail:        notFound,
+			status:      http.StatusNotFound,
+			contentType: "text/html; charset=utf-8",
+			contains:    []string{"<pre>id 42</pre>", "<pre>&#34;trace&#34;</pre>"},
+		},
+		{
+			name:   "BrowserFields",
+			accept: "text/html",
+			fail: func(rp *reply.Reply) error {
+				return rp.Err(reply.BadRequest("Invalid", reply.WithFields(reply.FieldsError{"email": "required"}))).Fail()
+			},
+			status:      http.StatusBadRequest,
+			contentType: "text/html; charset=utf-8",
+			contains:    []string{"<li><code>email</code> required</li>"},
+		},
+		{
+			name:        "API",
+			accept:      "application/json",
+			fail:        notFound,
+			status:      http.StatusNotFound,
+			contentType: "application/json; charset=utf-8",
+			contains:    []string{`"code":"NOT_FOUND"`},
+		},
+		{
+			name:        "NoAccept",
+			fail:        notFound,
+			status:      http.StatusNotFound,
+			contentType: "application/json; charset=utf-8",
+		},
+		{
+			name:        "CustomStatusPage",
+			client:      reply.Client{ErrorPages: reply.ErrorPages{http.StatusNotFound: "errors/404", 0: "errors/default"}},
+			templates:   true,
+			accept:      browserAccept,
+			fail:        notFound,
+			status:      http.StatusNotFound,
+			contentType: "text/html; charset=utf-8",
+			contains:    []string{"<h1>Lost: User not found</h1>"},
+		},
+		{
+			name:        "CustomDefaultPage",
+			client:      reply.Client{ErrorPages: reply.ErrorPages{http.StatusNotFound: "errors/404", 0: "errors/default"}},
+			templates:   true,
+			accept:      browserAccept,
+			fail:        conflict,
+			status:      http.StatusConflict,
+			contentType: "text/html; charset=utf-8",
+			contains:    []string{"<h1>409 Conflict</h1>"},
+		},
+		{
+			name:        "MissingTemplateFallsBack",
+			client:      reply.Client{ErrorPages: reply.ErrorPages{http.StatusNotFound: "errors/missing"}},
+			templates:   true,
+			accept:      browserAccept,
+			fail:        notFound,
+			status:      http.StatusNotFound,
+			contentType: "text/html; charset=utf-8",
+			contains:    []string{"<title>404 Not Found</title>"},
+		},
+		{
+			name:        "NoTemplatesFallsBack",
+			client:      reply.Client{ErrorPages: reply.ErrorPages{0: "errors/default"}},
+			accept:      browserAccept,
+			fail:        conflict,
+			status:      http.StatusConflict,
+			contentType: "text/html; charset=utf-8",
+			contains:    []string{"<title>409 Conflict</title>", "<p>Email taken</p>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.client.CodeAliases = reply.CodeAliases{"NOT_FOUND": http.StatusNotFound, "BAD_REQUEST": http.StatusBadRequest}
+			client := reply.NewClient(tt.client)
+			if tt.templates {
+				if err := client.LoadTemplates(errorViews(), reply.TemplateOptions{}); err != nil {
+					t.Fatalf("LoadTemplates: %v", err)
+				}
+			}
+			rp, rec := replytest.New(client, acceptRequest(tt.accept))
+			if err := tt.fail(rp); err != nil {
+				t.Fatalf("Fail: %v", err)
+			}
+
+			rec.AssertStatus(t, tt.status).AssertHeader(t, "Content-Type", tt.contentType)
+			body := string(rec.Body())
+			for _, want := range tt.contains {
+				if !strings.Contains(body, want) {
+					t.Errorf("body does not contain %q\nbody: %s", want, body)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(body, unwanted) {
+					t.Errorf("body contains %q\nbody: %s", unwanted, body)
+				}
+			}
+		})
+	}
+}
+
+func TestFailHTML(t *testing.T) {
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), acceptRequest("application/json"))
+	if err := rp.Success("not an error").FailHTML(http.StatusServiceUnavailable); err != nil {
+		t.Fatalf("FailHTML: %v", err)
+	}
+
+	rec.AssertStatus(t, http.StatusServiceUnavailable).AssertHeader(t, "Content-Type", "text/html; charset=utf-8")
+	if body := string(rec.Body()); !strings.Contains(body, "<p>Service Unavailable</p>") {
+		t.Errorf("body does not show the status text\nbody: %s", body)
+	}
+}
@@ -17,13 +17,16 @@ func (r *Reply) replyHTML(code int) error {
 			d = ""
 		}
 		escaped := html.EscapeString(d)
-		return r.a.HtmlSender(code, escaped)
+		return r.sendBody(code, "text/html; charset=utf-8", []byte(escaped), func() error {
+			return r.a.HtmlSender(code, escaped)
+		})
 	}, 2)
 }
 
 // ReplyHTML sends an HTML string response with the specified status code.
 // The Data in *Reply must be a string; if not, it will be treated as an empty string
-// and an error will be logged. The string is automatically escaped before sending.
+// and an error will be logged. The string is automatically escaped before sending,
+// so it is always shown as text. Use Render to send markup from templates.
 //
 // Example:
 //
@@ -5,9 +5,7 @@ import "net/http"
 // ReplyJSON sends a JSON-formatted response with the specified status code.
 // The Payload in *Reply will be automatically encoded.
 func (r *Reply) replyJSON(code int) error {
-	return r.send(func() error {
-		return r.a.JsonSender(code, r.Payload)
-	}, 2)
+	return r.replyEncoded(FormatJSON, code)
 }
 
 // ReplyJSON sends a JSON-formatted response with the specified status code.
@@ -31,8 +29,11 @@ func (r *Reply) CreatedJSON() error {
 
 // FailJSON sends a JSON response with an error status.
 // If code is provided, use it; otherwise, retrieve from CodeAliases
-// or default to 500.
+// or default to 500. Sends application/problem+json if Client.ProblemDetails is enabled.
 func (r *Reply) FailJSON(code ...int) error {
 	c, _ := r.retrieveStatusCode(code...)
+	if r.c.ProblemDetails {
+		return r.replyProblem(FormatJSON, c)
+	}
 	return r.replyJSON(c)
 }
@@ -0,0 +1,159 @@
+package reply
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/chesta132/goreply/adapter"
+)
+
+// replyJSONStream streams the data elements as the data array of a JSON envelope.
+// Elements are never held in memory together; the response is flushed every opts.FlushEvery elements.
+// An error yielded by the sequence is mapped like Err, written in an "error" field and returned.
+func (r *Reply) replyJSONStream(code int, opts JSONStreamOptions) error {
+	each, ok := elements(r.m.Data)
+	if !ok {
+		logError(ErrNotIterable, 3)
+		return ErrNotIterable
+	}
+	if opts.FlushEvery <= 0 {
+		opts.FlushEvery = 100
+	}
+	enc, _ := r.c.encoder(FormatJSON)
+
+	var seqErr streamError
+	err := r.send(func() error {
+		return r.writerSender(code, "application/json; charset=utf-8", func(w adapter.StreamWriter) error {
+			// read in write: the stream context of fiber is canceled on failed writes
+			ctx := r.a.Context()
+			sw := &jsonStreamWriter{w: w, enc: enc}
+			meta := r.streamMeta()
+
+			sw.raw(`{`)
+			if opts.Meta != MetaTail {
+				sw.field("meta", meta)
+				sw.raw(`,`)
+			}
+			sw.raw(`"data":[`)
+
+			count := 0
+			yielded, err := each(ctx, func(v any) error {
+				if count > 0 {
+					sw.raw(`,`)
+				}
+				sw.value(v)
+				count++
+				if count%opts.FlushEvery == 0 && sw.err == nil {
+					sw.err = w.Flush()
+				}
+				return sw.err
+			})
+			if err != nil {
+				return err
+			}
+			sw.raw(`]`)
+
+			if yielded != nil {
+				seqErr.set(yielded)
+				payload, _ := r.errorPayload(yielded)
+				sw.raw(`,`)
+				sw.field("error", payload)
+			}
+
+			if opts.Meta == MetaTail || opts.Meta == MetaTrailer {
+				// the reply meta is not changed once sent
+				pagination := Pagination{}
+				if meta.Pagination != nil {
+					pagination = *meta.Pagination
+				}
+				pagination.Total = count
+				meta.Pagination = &pagination
+			}
+			if opts.Meta == MetaTail {
+				sw.raw(`,`)
+				sw.field("meta", meta)
+			}
+			sw.raw("}\n")
+			if sw.err != nil {
+				return sw.err
+			}
+
+			if opts.Meta == MetaTrailer {
+				line, err := encodeLine(enc, meta)
+				if err != nil {
+					return err
+				}
+				w.SetTrailer("X-Reply-Meta", string(line))
+			}
+			return w.Flush()
+		})
+	}, 3)
+	if err != nil {
+		return err
+	}
+	return seqErr.get()
+}
+
+// streamMeta returns the meta to write in a streamed envelope, without debug info outside DebugMode.
+func (r *Reply) streamMeta() Meta {
+	meta := r.m.Meta
+	if !r.c.DebugMode {
+		meta.Debug = nil
+	}
+	return meta
+}
+
+// jsonStreamWriter writes JSON fragments, keeping the first error.
+type jsonStreamWriter struct {
+	w   io.Writer
+	enc Encoder // Client JSON encoder
+	err error
+}
+
+// raw writes s as is.
+func (s *jsonStreamWriter) raw(str string) {
+	if s.err == nil {
+		_, s.err = io.WriteString(s.w, str)
+	}
+}
+
+// value writes v encoded with the JSON encoder.
+func (s *jsonStreamWriter) value(v any) {
+	if s.err != nil {
+		return
+	}
+	var body bytes.Buffer
+	if s.err = s.enc.Encode(&body, v); s.err != nil {
+		return
+	}
+	_, s.err = s.w.Write(bytes.TrimRight(body.Bytes(), "\n"))
+}
+
+// field writes an object field with v encoded as JSON.
+func (s *jsonStreamWriter) field(name string, v any) {
+	s.value(name)
+	s.raw(`:`)
+	s.value(v)
+}
+
+// ReplyJSONStream streams the data as the data array of a JSON envelope with the specified status code.
+// Data must be an iter.Seq[T], iter.Seq2[T, error], a receive channel, a slice or an array;
+// returns ErrNotIterable otherwise. Streaming stops when the request context is done.
+// Transformer is not applied since the data is never materialized.
+// Elements are encoded with the client JSON encoder.
+// If the sequence yields an error, it is mapped like Err, written in an "error" field after data
+// and returned. On fiber the body is written after the handler returns, so the error is not returned.
+//
+// Example:
+//
+//	rp.Success(store.Orders(ctx)).ReplyJSONStream(http.StatusOK, reply.JSONStreamOptions{Meta: reply.MetaTail})
+//	// -> {"data":[{...},{...}],"meta":{"status":"SUCCESS","pagination":{...,"total":2},...}}
+func (r *Reply) ReplyJSONStream(code int, opts ...JSONStreamOptions) error {
+	return r.replyJSONStream(code, firstOption(opts))
+}
+
+// OkJSONStream is a shortcut for ReplyJSONStream with status 200 OK.
+func (r *Reply) OkJSONStream(opts ...JSONStreamOptions) error {
+	return r.replyJSONStream(http.StatusOK, firstOption(opts))
+}
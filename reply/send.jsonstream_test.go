@@ -0,0 +1,126 @@
+package reply_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nethttpadapter "github.com/chesta132/goreply/adapter/nethttp"
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+// streamEnvelope is a decoded JSON stream envelope.
+type streamEnvelope struct {
+	Meta  *reply.Meta         `json:"meta"`
+	Data  []streamItem        `json:"data"`
+	Error *reply.ErrorPayload `json:"error"`
+}
+
+func TestReplyJSONStream(t *testing.T) {
+	items := []streamItem{{ID: 1, Name: "Jane"}, {ID: 2, Name: "John"}}
+
+	tests := []struct {
+		name      string
+		encoder   reply.Encoder
+		data      any
+		opts      reply.JSONStreamOptions
+		prefix    string // start of the body, checks the meta placement
+		wantTotal int
+		wantErr   string
+		wantCode  string
+	}{
+		{name: "MetaHead", data: items, prefix: `{"meta":{"status":"SUCCESS"`},
+		{name: "MetaTail", data: itemSeq(items...), opts: reply.JSONStreamOptions{Meta: reply.MetaTail}, prefix: `{"data":[{"id":1`, wantTotal: 2},
+		{name: "Empty", data: []streamItem{}, opts: reply.JSONStreamOptions{Meta: reply.MetaTail}, prefix: `{"data":[],"meta"`},
+		{name: "FlushEvery", data: itemChan(items...), opts: reply.JSONStreamOptions{FlushEvery: 1}, prefix: `{"meta":`},
+		{
+			name:     "Seq2Error",
+			data:     itemSeqErr(reply.Conflict("Stale read"), items[0]),
+			prefix:   `{"meta":`,
+			wantErr:  "CONFLICT: Stale read",
+			wantCode: "CONFLICT",
+		},
+		{
+			name:     "Seq2ErrorMetaTail",
+			data:     itemSeqErr(errors.New("db down"), items...),
+			opts:     reply.JSONStreamOptions{Meta: reply.MetaTail},
+			prefix:   `{"data":[`,
+			wantErr:  "db down",
+			wantCode: "SERVER_ERROR",
+		},
+		{
+			name:    "IndentedEncoder",
+			encoder: reply.JSONEncoder{Indent: "  "},
+			data:    items,
+			prefix:  `{"meta":{`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(reply.Client{})
+			if tt.encoder != nil {
+				client.RegisterEncoder(reply.FormatJSON, tt.encoder)
+			}
+			rp, rec := replytest.New(client, nil)
+			if err := rp.Success(tt.data).OkJSONStream(tt.opts); errString(err) != tt.wantErr {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+
+			rec.AssertStatus(t, http.StatusOK).AssertHeader(t, "Content-Type", "application/json; charset=utf-8")
+			body := string(rec.Body())
+			if !strings.HasPrefix(body, tt.prefix) {
+				t.Errorf("body = %s, want prefix %s", body, tt.prefix)
+			}
+			var got streamEnvelope
+			if err := json.Unmarshal(rec.Body(), &got); err != nil {
+				t.Fatalf("decode: %v\nbody: %s", err, body)
+			}
+			if got.Meta == nil || got.Meta.Status != "SUCCESS" {
+				t.Fatalf("meta = %+v, want status SUCCESS", got.Meta)
+			}
+			if tt.wantTotal > 0 && (got.Meta.Pagination == nil || got.Meta.Pagination.Total != tt.wantTotal) {
+				t.Errorf("meta.pagination = %+v, want total %d", got.Meta.Pagination, tt.wantTotal)
+			}
+			var code string
+			if got.Error != nil {
+				code = got.Error.Code
+			}
+			if code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestReplyJSONStreamTrailer(t *testing.T) {
+	rec := httptest.NewRecorder()
+	a := nethttpadapter.AdaptHttp(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	rp := reply.NewClient(reply.Client{}).New(a)
+	if err := rp.Success([]streamItem{{ID: 1}, {ID: 2}}).OkJSONStream(reply.JSONStreamOptions{Meta: reply.MetaTrailer}); err != nil {
+		t.Fatalf("OkJSONStream: %v", err)
+	}
+
+	var meta reply.Meta
+	trailer := rec.Result().Trailer.Get("X-Reply-Meta")
+	if err := json.Unmarshal([]byte(trailer), &meta); err != nil {
+		t.Fatalf("decode trailer %q: %v", trailer, err)
+	}
+	if meta.Status != "SUCCESS" || meta.Pagination == nil || meta.Pagination.Total != 2 {
+		t.Errorf("trailer meta = %+v, want total 2", meta)
+	}
+}
+
+func TestReplyJSONStreamNotIterable(t *testing.T) {
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+	if err := rp.Success(streamItem{ID: 1}).OkJSONStream(); !errors.Is(err, reply.ErrNotIterable) {
+		t.Errorf("err = %v, want ErrNotIterable", err)
+	}
+	if len(rec.Body()) != 0 {
+		t.Errorf("body = %s, want nothing sent", rec.Body())
+	}
+}
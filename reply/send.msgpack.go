@@ -0,0 +1,36 @@
+package reply
+
+import "net/http"
+
+// ReplyMsgPack sends a MessagePack-encoded response with the specified status code.
+// Requires an encoder registered for FormatMsgPack, e.g. with msgpackencoder.Register.
+// Returns ErrEncoderNotFound otherwise.
+//
+// Example:
+//
+//	msgpackencoder.Register(Client)
+//	rp.Success(Data{Msg: "ok"}).ReplyMsgPack(http.StatusOK)
+func (r *Reply) ReplyMsgPack(code int) error {
+	return r.replyEncoded(FormatMsgPack, code)
+}
+
+// OkMsgPack is a shortcut for ReplyMsgPack with status 200 OK.
+func (r *Reply) OkMsgPack() error {
+	return r.replyEncoded(FormatMsgPack, http.StatusOK)
+}
+
+// CreatedMsgPack sends status 201 Created with MessagePack body.
+func (r *Reply) CreatedMsgPack() error {
+	return r.replyEncoded(FormatMsgPack, http.StatusCreated)
+}
+
+// FailMsgPack sends a MessagePack response with an error status.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500. Renders problem details if Client.ProblemDetails is enabled.
+func (r *Reply) FailMsgPack(code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	if r.c.ProblemDetails {
+		return r.replyProblem(FormatMsgPack, c)
+	}
+	return r.replyEncoded(FormatMsgPack, c)
+}
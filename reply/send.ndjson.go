@@ -0,0 +1,85 @@
+package reply
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/chesta132/goreply/adapter"
+)
+
+// replyNDJSON streams each element of the data as one JSON line, flushing after every line.
+// An error yielded by the sequence is mapped like Err, written as a final ErrorPayload line and returned.
+func (r *Reply) replyNDJSON(code int) error {
+	each, ok := elements(r.m.Data)
+	if !ok {
+		logError(ErrNotIterable, 3)
+		return ErrNotIterable
+	}
+	enc, _ := r.c.encoder(FormatJSON)
+
+	var seqErr streamError
+	err := r.send(func() error {
+		return r.writerSender(code, "application/x-ndjson", func(w adapter.StreamWriter) error {
+			// read in write: the stream context of fiber is canceled on failed writes
+			ctx := r.a.Context()
+			writeLine := func(v any) error {
+				line, err := encodeLine(enc, v)
+				if err != nil {
+					return err
+				}
+				if _, err := w.Write(append(line, '\n')); err != nil {
+					return err
+				}
+				return w.Flush()
+			}
+
+			yielded, err := each(ctx, writeLine)
+			if err != nil || yielded == nil {
+				return err
+			}
+
+			seqErr.set(yielded)
+			payload, _ := r.errorPayload(yielded)
+			return writeLine(payload)
+		})
+	}, 3)
+	if err != nil {
+		return err
+	}
+	return seqErr.get()
+}
+
+// encodeLine encodes v with the JSON encoder enc as one line of compact JSON, without the line break.
+func encodeLine(enc Encoder, v any) ([]byte, error) {
+	var body, line bytes.Buffer
+	if err := enc.Encode(&body, v); err != nil {
+		return nil, err
+	}
+	if err := json.Compact(&line, body.Bytes()); err != nil {
+		return nil, err
+	}
+	return line.Bytes(), nil
+}
+
+// ReplyNDJSON streams the data as newline-delimited JSON with the specified status code.
+// Data must be an iter.Seq[T], iter.Seq2[T, error], a receive channel, a slice or an array;
+// returns ErrNotIterable otherwise. Streaming stops when the request context is done.
+// Lines are encoded with the client JSON encoder.
+// If the sequence yields an error, it is mapped like Err, written as a final ErrorPayload line
+// and returned. On fiber the body is written after the handler returns, so the error is not returned.
+//
+// Example:
+//
+//	rp.Success(store.Users(ctx)).ReplyNDJSON(http.StatusOK)
+//	// {"id":1,"name":"Chesta"}
+//	// {"id":2,"name":"Reply"}
+//	// {"code":"SERVER_ERROR","message":"Internal server error"}
+func (r *Reply) ReplyNDJSON(code int) error {
+	return r.replyNDJSON(code)
+}
+
+// OkNDJSON is a shortcut for ReplyNDJSON with status 200 OK.
+func (r *Reply) OkNDJSON() error {
+	return r.replyNDJSON(http.StatusOK)
+}
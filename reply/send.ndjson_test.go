@@ -0,0 +1,154 @@
+package reply_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+type streamItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// itemSeq yields the items.
+func itemSeq(items ...streamItem) iter.Seq[streamItem] {
+	return func(yield func(streamItem) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// itemSeqErr yields the items, then err.
+func itemSeqErr(err error, items ...streamItem) iter.Seq2[streamItem, error] {
+	return func(yield func(streamItem, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+		yield(streamItem{}, err)
+	}
+}
+
+// itemChan returns a closed channel buffering the items.
+func itemChan(items ...streamItem) <-chan streamItem {
+	ch := make(chan streamItem, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+// errString returns the message of err, or "" for nil.
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func TestReplyNDJSON(t *testing.T) {
+	items := []streamItem{{ID: 1, Name: "Jane"}, {ID: 2, Name: "<John>"}}
+	lines := `{"id":1,"name":"Jane"}` + "\n" + `{"id":2,"name":"\u003cJohn\u003e"}` + "\n"
+
+	tests := []struct {
+		name    string
+		encoder reply.Encoder
+		data    any
+		body    string
+		wantErr string
+	}{
+		{name: "Slice", data: items, body: lines},
+		{name: "Array", data: [2]streamItem{items[0], items[1]}, body: lines},
+		{name: "Chan", data: itemChan(items...), body: lines},
+		{name: "Seq", data: itemSeq(items...), body: lines},
+		{name: "Empty", data: []streamItem{}},
+		{
+			name:    "Seq2Error",
+			data:    itemSeqErr(reply.NotFound("User not found"), items[0]),
+			body:    `{"id":1,"name":"Jane"}` + "\n" + `{"code":"NOT_FOUND","message":"User not found"}` + "\n",
+			wantErr: "NOT_FOUND: User not found",
+		},
+		{
+			name:    "Seq2UnknownError",
+			data:    itemSeqErr(errors.New("db down")),
+			body:    `{"code":"SERVER_ERROR","message":"Internal server error"}` + "\n",
+			wantErr: "db down",
+		},
+		{
+			name:    "IndentedEncoderCompacted",
+			encoder: reply.JSONEncoder{Indent: "  ", DisableHTMLEscape: true},
+			data:    items,
+			body:    `{"id":1,"name":"Jane"}` + "\n" + `{"id":2,"name":"<John>"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(reply.Client{})
+			if tt.encoder != nil {
+				client.RegisterEncoder(reply.FormatJSON, tt.encoder)
+			}
+			rp, rec := replytest.New(client, nil)
+			if err := rp.Success(tt.data).OkNDJSON(); errString(err) != tt.wantErr {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+
+			rec.AssertStatus(t, http.StatusOK).AssertHeader(t, "Content-Type", "application/x-ndjson")
+			if got := string(rec.Body()); got != tt.body {
+				t.Errorf("body = %q, want %q", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestReplyNDJSONCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), req)
+
+	// the channel is never closed, so only the canceled context ends the stream
+	err := rp.Success(make(chan streamItem)).OkNDJSON()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if len(rec.Body()) != 0 {
+		t.Errorf("body = %q, want empty", rec.Body())
+	}
+}
+
+func TestReplyNDJSONNotIterable(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+	}{
+		{"Nil", nil},
+		{"Struct", streamItem{ID: 1}},
+		{"SendOnlyChan", make(chan<- streamItem)},
+		{"Func", func() {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+			if err := rp.Success(tt.data).OkNDJSON(); !errors.Is(err, reply.ErrNotIterable) {
+				t.Errorf("err = %v, want ErrNotIterable", err)
+			}
+			if len(rec.Body()) != 0 {
+				t.Errorf("body = %s, want nothing sent", rec.Body())
+			}
+		})
+	}
+}
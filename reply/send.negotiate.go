@@ -0,0 +1,83 @@
+package reply
+
+import "net/http"
+
+// replyNegotiated sends the response in the format picked from the request Accept header.
+// Answers 406 Not Acceptable with an ErrorPayload if no format matches.
+func (r *Reply) replyNegotiated(code int) error {
+	r.a.AddHeader("Vary", "Accept")
+
+	format, ok := negotiateFormat(r.a.RequestHeader("Accept"), r.offers(), r.c.mediaTypes)
+	if !ok {
+		return r.notAcceptable(false)
+	}
+	return r.replyFormat(format, code)
+}
+
+// replyFormat sends the response with the sender of the given format.
+func (r *Reply) replyFormat(format Format, code int) error {
+	switch format {
+	case FormatText:
+		return r.replyText(code)
+	case FormatHTML:
+		if _, ok := r.m.Data.(ErrorPayload); ok {
+			return r.replyErrorPage(code)
+		}
+		return r.replyHTML(code)
+	default:
+		return r.replyEncoded(format, code)
+	}
+}
+
+// notAcceptable replaces the payload with a NOT_ACCEPTABLE error and sends it with status 406.
+// The error is rendered in the default format, or JSON if the default can not render it.
+// Problem details are used if problem is true or enabled on the client.
+func (r *Reply) notAcceptable(problem bool) error {
+	r.Error("NOT_ACCEPTABLE", "None of the accepted media types can be produced")
+	format := r.c.defaultFormat()
+	if format != FormatXML {
+		format = FormatJSON
+	}
+	if problem || r.c.ProblemDetails {
+		return r.replyProblem(format, http.StatusNotAcceptable)
+	}
+	return r.replyFormat(format, http.StatusNotAcceptable)
+}
+
+// Reply sends the response in the format negotiated from the request Accept header.
+// Offers JSON, XML and formats of registered encoders, plus HTML and text when data is a string.
+// Falls back to Client.DefaultFormat when Accept is empty or a wildcard,
+// and answers 406 Not Acceptable when nothing matches.
+//
+// Example:
+//
+//	rp.Success(user).Reply(http.StatusOK) // Accept: application/xml -> <ReplyEnvelope>...
+func (r *Reply) Reply(code int) error {
+	return r.replyNegotiated(code)
+}
+
+// Ok is a shortcut for Reply with status 200 OK.
+func (r *Reply) Ok() error {
+	return r.replyNegotiated(http.StatusOK)
+}
+
+// Created is a shortcut for Reply with status 201 Created.
+func (r *Reply) Created() error {
+	return r.replyNegotiated(http.StatusCreated)
+}
+
+// Fail sends a negotiated response with an error status.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500. Renders problem details if Client.ProblemDetails is enabled.
+// Requests accepting HTML, like browsers, get an HTML error page.
+//
+// Example:
+//
+//	rp.Error("NOT_FOUND", "User not found").Fail() // Accept: text/html -> 404 error page
+func (r *Reply) Fail(code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	if r.c.ProblemDetails {
+		return r.replyProblemNegotiated(c, true)
+	}
+	return r.replyNegotiated(c)
+}
@@ -0,0 +1,83 @@
+package reply_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+// textEncoder is a custom encoder writing a fixed body.
+type textEncoder struct{}
+
+func (textEncoder) ContentType() string { return "text/x-custom" }
+
+func (textEncoder) Encode(w io.Writer, v any) error {
+	_, err := io.WriteString(w, "custom")
+	return err
+}
+
+// acceptRequest builds a GET / request with the given Accept header.
+func acceptRequest(accept string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	return req
+}
+
+func TestReplyNegotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      reply.Client
+		custom      bool // registers textEncoder as "custom"
+		data        any
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"EmptyAccept", reply.Client{}, false, "hi", "", http.StatusOK, "application/json; charset=utf-8"},
+		{"Wildcard", reply.Client{}, false, "hi", "*/*", http.StatusOK, "application/json; charset=utf-8"},
+		{"WildcardDefaultFormat", reply.Client{DefaultFormat: reply.FormatXML}, false, "hi", "*/*", http.StatusOK, "application/xml; charset=utf-8"},
+		{"ExactXML", reply.Client{}, false, "hi", "application/xml", http.StatusOK, "application/xml; charset=utf-8"},
+		{"TextXML", reply.Client{}, false, "hi", "text/xml", http.StatusOK, "application/xml; charset=utf-8"},
+		{"HighestQuality", reply.Client{}, false, "hi", "application/json;q=0.5, application/xml;q=0.9", http.StatusOK, "application/xml; charset=utf-8"},
+		{"SpecificRangeWins", reply.Client{}, false, "hi", "application/*;q=0.8, application/json;q=0", http.StatusOK, "application/xml; charset=utf-8"},
+		{"TieKeepsDefault", reply.Client{}, false, "hi", "application/xml, application/json", http.StatusOK, "application/json; charset=utf-8"},
+		{"HTMLString", reply.Client{}, false, "hi", "text/html", http.StatusOK, "text/html; charset=utf-8"},
+		{"TextString", reply.Client{}, false, "hi", "text/plain", http.StatusOK, "text/plain; charset=utf-8"},
+		{"TextNotForStructs", reply.Client{}, false, map[string]int{"a": 1}, "text/plain", http.StatusNotAcceptable, "application/json; charset=utf-8"},
+		{"Unacceptable", reply.Client{}, false, "hi", "image/png", http.StatusNotAcceptable, "application/json; charset=utf-8"},
+		{"ZeroQuality", reply.Client{}, false, "hi", "application/json;q=0, application/xml;q=0", http.StatusNotAcceptable, "application/json; charset=utf-8"},
+		{"MalformedQualitySkipped", reply.Client{}, false, "hi", "application/xml;q=2, application/json", http.StatusOK, "application/json; charset=utf-8"},
+		{"UnacceptableXMLDefault", reply.Client{DefaultFormat: reply.FormatXML}, false, "hi", "image/png", http.StatusNotAcceptable, "application/xml; charset=utf-8"},
+		{"RegisteredEncoder", reply.Client{}, true, "hi", "text/x-custom", http.StatusOK, "text/x-custom"},
+		{"RegisteredEncoderAlias", reply.Client{}, true, "hi", "application/x-custom", http.StatusOK, "text/x-custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(tt.client)
+			if tt.custom {
+				client.RegisterEncoder("custom", textEncoder{}, "application/x-custom")
+			}
+			rp, rec := replytest.New(client, acceptRequest(tt.accept))
+			if err := rp.Success(tt.data).Ok(); err != nil {
+				t.Fatalf("Ok: %v", err)
+			}
+			rec.AssertStatus(t, tt.status).
+				AssertHeader(t, "Content-Type", tt.contentType).
+				AssertHeader(t, "Vary", "Accept")
+		})
+	}
+}
+
+func TestReplyNotAcceptableError(t *testing.T) {
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), acceptRequest("image/png"))
+	rp.Success("hi").Ok()
+
+	rec.AssertStatus(t, http.StatusNotAcceptable).AssertErrorCode(t, "NOT_ACCEPTABLE")
+}
@@ -0,0 +1,99 @@
+package reply
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+)
+
+// replyProblem sends the error payload as problem details with the specified status code.
+// JSON and XML are sent as application/problem+json and application/problem+xml,
+// other formats with their encoder content type. Returns ErrEncoderNotFound without encoder.
+func (r *Reply) replyProblem(format Format, code int) error {
+	enc, ok := r.c.encoder(format)
+	if !ok {
+		err := fmt.Errorf("%w: %q", ErrEncoderNotFound, format)
+		logError(err, 3)
+		return err
+	}
+
+	return r.send(func() error {
+		p := r.problem(code)
+
+		contentType := enc.ContentType()
+		switch format {
+		case FormatJSON:
+			contentType = "application/problem+json"
+		case FormatXML:
+			contentType = "application/problem+xml"
+			if p.Debug != nil {
+				p.Debug = debugText(p.Debug)
+			}
+		}
+		r.Payload = p
+
+		var body bytes.Buffer
+		if err := enc.Encode(&body, r.Payload); err != nil {
+			return err
+		}
+		return r.sendBody(code, contentType, body.Bytes(), func() error {
+			return r.a.BytesSender(code, contentType, body.Bytes())
+		})
+	}, 2)
+}
+
+// replyProblemNegotiated sends problem details in the format picked from the request Accept header.
+// Formats of registered encoders are offered after JSON and XML.
+// If html is true, an HTML error page is offered too.
+func (r *Reply) replyProblemNegotiated(code int, html bool) error {
+	r.a.AddHeader("Vary", "Accept")
+
+	candidates := []Format{FormatJSON, FormatXML}
+	for _, f := range r.c.formats {
+		if !slices.Contains(candidates, f) && f != FormatText && f != FormatHTML {
+			candidates = append(candidates, f)
+		}
+	}
+	if html {
+		candidates = append(candidates, FormatHTML)
+	}
+	offers := r.c.preferDefault(candidates)
+	format, ok := negotiateFormat(r.a.RequestHeader("Accept"), offers, r.c.problemMediaTypes)
+	if !ok {
+		return r.notAcceptable(true)
+	}
+	if format == FormatHTML {
+		return r.replyErrorPage(code)
+	}
+	return r.replyProblem(format, code)
+}
+
+// FailProblem sends the error as RFC 9457 problem details,
+// negotiating application/problem+json or application/problem+xml from the Accept header.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500.
+//
+// Example:
+//
+//	rp.Error("NOT_FOUND", "User not found").FailProblem()
+//	// -> {"type":"about:blank","title":"Not Found","status":404,"detail":"User not found","instance":"/users/1","code":"NOT_FOUND"}
+func (r *Reply) FailProblem(code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	return r.replyProblemNegotiated(c, false)
+}
+
+// FailProblemJSON sends the error as application/problem+json.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500.
+func (r *Reply) FailProblemJSON(code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	return r.replyProblem(FormatJSON, c)
+}
+
+// FailProblemXML sends the error as application/problem+xml.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500.
+func (r *Reply) FailProblemXML(code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	return r.replyProblem(FormatXML, c)
+}
@@ -0,0 +1,164 @@
+package reply_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+func TestFailProblem(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      reply.Client
+		build       func(rp *reply.Reply) *reply.Reply
+		accept      string
+		status      int
+		contentType string
+		want        reply.Problem
+	}{
+		{
+			name:        "AboutBlank",
+			build:       func(rp *reply.Reply) *reply.Reply { return rp.Error("NOT_FOUND", "User not found") },
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			want:        reply.Problem{Type: "about:blank", Title: "Not Found", Status: 404, Detail: "User not found", Instance: "/users/42", Code: "NOT_FOUND"},
+		},
+		{
+			name: "AboutBlankWithDetails",
+			build: func(rp *reply.Reply) *reply.Reply {
+				return rp.Error("NOT_FOUND", "User not found", reply.WithDetails("id 42"))
+			},
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			want:        reply.Problem{Type: "about:blank", Title: "Not Found", Status: 404, Detail: "User not found: id 42", Instance: "/users/42", Code: "NOT_FOUND"},
+		},
+		{
+			name:   "TypeURI",
+			client: reply.Client{ProblemTypeURI: "https://api.example.com/problems/"},
+			build: func(rp *reply.Reply) *reply.Reply {
+				return rp.Error("INVALID_INPUT", "Invalid user", reply.WithFields(reply.FieldsError{"name": "required"}))
+			},
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			want: reply.Problem{
+				Type: "https://api.example.com/problems/invalid-input", Title: "Invalid user", Status: 404,
+				Instance: "/users/42", Code: "INVALID_INPUT", Errors: reply.FieldsError{"name": "required"},
+			},
+		},
+		{
+			name:        "NotAnErrorPayload",
+			build:       func(rp *reply.Reply) *reply.Reply { return rp.Success("ok") },
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			want:        reply.Problem{Type: "about:blank", Title: "Not Found", Status: 404, Instance: "/users/42"},
+		},
+		{
+			name:        "NegotiatedXML",
+			build:       func(rp *reply.Reply) *reply.Reply { return rp.Error("NOT_FOUND", "User not found") },
+			accept:      "application/problem+xml",
+			status:      http.StatusNotFound,
+			contentType: "application/problem+xml",
+		},
+		{
+			name:        "UnacceptableFallsBackToJSON",
+			build:       func(rp *reply.Reply) *reply.Reply { return rp.Error("NOT_FOUND", "User not found") },
+			accept:      "image/png",
+			status:      http.StatusNotAcceptable,
+			contentType: "application/problem+json",
+			want: reply.Problem{
+				Type: "about:blank", Title: "Not Acceptable", Status: 406, Instance: "/users/42",
+				Detail: "None of the accepted media types can be produced", Code: "NOT_ACCEPTABLE",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := acceptRequest(tt.accept)
+			req.URL.Path = "/users/42"
+			rp, rec := replytest.New(reply.NewClient(tt.client), req)
+			if err := tt.build(rp).FailProblem(http.StatusNotFound); err != nil {
+				t.Fatalf("FailProblem: %v", err)
+			}
+
+			rec.AssertStatus(t, tt.status).AssertHeader(t, "Content-Type", tt.contentType)
+			if tt.contentType != "application/problem+json" {
+				return
+			}
+			var got reply.Problem
+			if err := json.Unmarshal(rec.Body(), &got); err != nil {
+				t.Fatalf("decode problem: %v\nbody: %s", err, rec.Body())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problem = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProblemDetailsClient(t *testing.T) {
+	client := reply.NewClient(reply.Client{ProblemDetails: true, CodeAliases: reply.CodeAliases{"NOT_FOUND": 404}})
+
+	tests := []struct {
+		name        string
+		fail        func(rp *reply.Reply) error
+		contentType string
+	}{
+		{"Fail", func(rp *reply.Reply) error { return rp.Fail() }, "application/problem+json"},
+		{"FailJSON", func(rp *reply.Reply) error { return rp.FailJSON() }, "application/problem+json"},
+		{"FailXML", func(rp *reply.Reply) error { return rp.FailXML() }, "application/problem+xml"},
+		{"FailAsXML", func(rp *reply.Reply) error { return rp.FailAs(reply.FormatXML) }, "application/problem+xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, rec := replytest.New(client, nil)
+			if err := tt.fail(rp.Error("NOT_FOUND", "User not found")); err != nil {
+				t.Fatalf("fail: %v", err)
+			}
+			rec.AssertStatus(t, http.StatusNotFound).AssertHeader(t, "Content-Type", tt.contentType)
+		})
+	}
+}
+
+func TestProblemXMLDebug(t *testing.T) {
+	client := reply.NewClient(reply.Client{DebugMode: true})
+	rp, rec := replytest.New(client, nil)
+	rp.Error("SERVER_ERROR", "Internal server error").
+		Debug(map[string]any{"panic": "boom", "stack": []string{"main.go:1 <main>"}}).
+		FailProblemXML(http.StatusInternalServerError)
+
+	rec.AssertStatus(t, http.StatusInternalServerError)
+	var got struct {
+		Title string `xml:"title"`
+		Debug string `xml:"debug"`
+	}
+	if err := xml.Unmarshal(rec.Body(), &got); err != nil {
+		t.Fatalf("decode problem+xml: %v\nbody: %s", err, rec.Body())
+	}
+	if got.Title != "Internal Server Error" {
+		t.Errorf("title = %q, want Internal Server Error", got.Title)
+	}
+	var debug map[string]any
+	if err := json.Unmarshal([]byte(got.Debug), &debug); err != nil || debug["panic"] != "boom" {
+		t.Errorf("debug = %q, want the JSON of the debug map", got.Debug)
+	}
+}
+
+func TestProblemDebugHidden(t *testing.T) {
+	rp, rec := replytest.New(reply.NewClient(reply.Client{}), nil)
+	rp.Error("SERVER_ERROR", "Internal server error").Debug("secret").FailProblemJSON(http.StatusInternalServerError)
+
+	var got reply.Problem
+	if err := json.Unmarshal(rec.Body(), &got); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if got.Debug != nil {
+		t.Errorf("debug = %v, want none outside DebugMode", got.Debug)
+	}
+}
@@ -0,0 +1,81 @@
+package reply
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/chesta132/goreply/adapter"
+)
+
+// SSE opens a server-sent events stream and runs fn to send events.
+// The stream ends when fn returns. fn should return once EventStream.Context is done,
+// which happens when the client disconnects or a write fails.
+// If fn returns an error while the client is connected, it is mapped like Err
+// and sent as an "error" event.
+// On fiber, fn runs after the handler returns, so it must not use the fiber context.
+//
+// Example:
+//
+//	rp.SSE(func(s *reply.EventStream) error {
+//		for msg := range hub.Subscribe(s.Context(), s.LastEventID()) {
+//			if err := s.Send("message", msg.ID, msg); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	}, reply.SSEOptions{Heartbeat: 15 * time.Second})
+func (r *Reply) SSE(fn func(s *EventStream) error, opts ...SSEOptions) error {
+	opt := firstOption(opts)
+	enc, _ := r.c.encoder(FormatJSON)
+
+	return r.send(func() error {
+		// the adapter may run write after the handler returns, keep request values at hand
+		lastEventID := r.a.RequestHeader("Last-Event-ID")
+
+		r.a.SetHeader("Cache-Control", "no-cache")
+		r.a.SetHeader("X-Accel-Buffering", "no")
+		return r.writerSender(http.StatusOK, "text/event-stream; charset=utf-8", func(w adapter.StreamWriter) error {
+			// read in write: the stream context of fiber is canceled on failed writes
+			ctx, cancel := context.WithCancel(r.a.Context())
+			var heartbeats sync.WaitGroup
+			// no write may outlive the response
+			defer func() {
+				cancel()
+				heartbeats.Wait()
+			}()
+
+			s := &EventStream{
+				w:           w,
+				enc:         enc,
+				envelope:    opt.Envelope,
+				lastEventID: lastEventID,
+				ctx:         ctx,
+				cancel:      cancel,
+			}
+
+			// open the stream right away so clients see the connection
+			var err error
+			if opt.Retry > 0 {
+				err = s.Retry(opt.Retry)
+			} else {
+				err = s.Comment("open")
+			}
+			if err != nil {
+				return err
+			}
+			if opt.Heartbeat > 0 {
+				heartbeats.Go(func() { s.heartbeat(opt.Heartbeat) })
+			}
+
+			fnErr := fn(s)
+			// a disconnected client is a normal end of the stream
+			if fnErr == nil || s.ctx.Err() != nil {
+				return nil
+			}
+
+			payload, _ := r.errorPayload(fnErr)
+			return s.Send("error", "", payload)
+		})
+	}, 2)
+}
@@ -0,0 +1,130 @@
+package reply_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+func TestReplySSE(t *testing.T) {
+	tests := []struct {
+		name        string
+		encoder     reply.Encoder
+		opts        reply.SSEOptions
+		lastEventID string
+		fn          func(s *reply.EventStream) error
+		body        string
+		contains    []string // checked instead of body when set
+	}{
+		{
+			name: "Events",
+			fn: func(s *reply.EventStream) error {
+				if err := s.Send("price", "1", streamItem{ID: 1, Name: "GO"}); err != nil {
+					return err
+				}
+				return s.Send("", "", "plain")
+			},
+			body: ": open\n\n" +
+				"event: price\nid: 1\ndata: {\"id\":1,\"name\":\"GO\"}\n\n" +
+				"data: \"plain\"\n\n",
+		},
+		{
+			name: "LineBreaksStripped",
+			fn: func(s *reply.EventStream) error {
+				if err := s.Comment("a\nb"); err != nil {
+					return err
+				}
+				return s.Send("multi\nline", "4\r\n2", 1)
+			},
+			body: ": open\n\n: a b\n\nevent: multi line\nid: 4 2\ndata: 1\n\n",
+		},
+		{
+			name:    "MultiLineData",
+			encoder: reply.JSONEncoder{Indent: "  "},
+			fn:      func(s *reply.EventStream) error { return s.Send("", "", streamItem{ID: 1, Name: "GO"}) },
+			body:    ": open\n\ndata: {\ndata:   \"id\": 1,\ndata:   \"name\": \"GO\"\ndata: }\n\n",
+		},
+		{
+			name: "Retry",
+			opts: reply.SSEOptions{Retry: 3 * time.Second},
+			fn:   func(s *reply.EventStream) error { return nil },
+			body: "retry: 3000\n\n",
+		},
+		{
+			name:        "LastEventID",
+			lastEventID: "41",
+			fn:          func(s *reply.EventStream) error { return s.Send("", s.LastEventID(), 42) },
+			body:        ": open\n\nid: 41\ndata: 42\n\n",
+		},
+		{
+			name: "ErrorEvent",
+			fn:   func(s *reply.EventStream) error { return reply.NotFound("Channel not found") },
+			body: ": open\n\nevent: error\ndata: {\"code\":\"NOT_FOUND\",\"message\":\"Channel not found\"}\n\n",
+		},
+		{
+			name:     "Envelope",
+			opts:     reply.SSEOptions{Envelope: true},
+			fn:       func(s *reply.EventStream) error { return s.Send("", "", "hi") },
+			contains: []string{`data: {"meta":{"status":"SUCCESS","timestamp":`, `"data":"hi"}`},
+		},
+		{
+			name:     "EnvelopeError",
+			opts:     reply.SSEOptions{Envelope: true},
+			fn:       func(s *reply.EventStream) error { return reply.Conflict("Stale") },
+			contains: []string{"event: error\n", `data: {"meta":{"status":"ERROR"`, `"data":{"code":"CONFLICT","message":"Stale"}}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(reply.Client{})
+			if tt.encoder != nil {
+				client.RegisterEncoder(reply.FormatJSON, tt.encoder)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			rp, rec := replytest.New(client, req)
+			if err := rp.SSE(tt.fn, tt.opts); err != nil {
+				t.Fatalf("SSE: %v", err)
+			}
+
+			rec.AssertStatus(t, http.StatusOK).
+				AssertHeader(t, "Content-Type", "text/event-stream; charset=utf-8").
+				AssertHeader(t, "Cache-Control", "no-cache")
+			body := string(rec.Body())
+			if tt.contains == nil && body != tt.body {
+				t.Errorf("body = %q, want %q", body, tt.body)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(body, want) {
+					t.Errorf("body = %q, want it to contain %q", body, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReplySSEContextDone(t *testing.T) {
+	rp, _ := replytest.New(reply.NewClient(reply.Client{}), nil)
+	var stream *reply.EventStream
+	if err := rp.SSE(func(s *reply.EventStream) error {
+		stream = s
+		return nil
+	}); err != nil {
+		t.Fatalf("SSE: %v", err)
+	}
+
+	if stream.Context().Err() == nil {
+		t.Error("stream context is not done after fn returned")
+	}
+	if err := stream.Send("", "", "late"); err == nil {
+		t.Error("Send after the stream ended succeeded")
+	}
+}
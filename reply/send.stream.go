@@ -14,7 +14,7 @@ func (r *Reply) replyStream(code int) error {
 			logError(errors.New("DATA TYPE IS NOT STREAM"), 2)
 			return r.a.StreamSender(code, "", nil)
 		}
-		return r.a.StreamSender(code, d.ContentType, d.Data)
+		return r.streamBody(code, d.ContentType, d.Data)
 	}, 2)
 }
 
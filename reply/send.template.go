@@ -0,0 +1,69 @@
+package reply
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+)
+
+// renderTemplate executes the named page template with the reply data, meta and request info
+// and sends the HTML with the specified status code.
+func (r *Reply) renderTemplate(code int, name string) error {
+	if r.c.templates == nil {
+		logError(ErrNoTemplates, 3)
+		return ErrNoTemplates
+	}
+	page, exec, err := r.c.templates.lookup(name)
+	if err != nil {
+		logError(err, 3)
+		return err
+	}
+	return r.send(func() error {
+		return r.sendTemplate(code, page, exec, r.m.Data)
+	}, 3)
+}
+
+// sendTemplate executes a template with data, the reply meta and request info, and sends the HTML.
+// Meta debug info is only available in DebugMode.
+func (r *Reply) sendTemplate(code int, t *template.Template, name string, data any) error {
+	rd := RenderData{
+		Data: data,
+		Meta: r.m.Meta,
+		Request: RequestInfo{
+			Method: r.a.Method(),
+			Path:   r.a.Path(),
+			Query:  r.a.Query(),
+		},
+	}
+	if !r.c.DebugMode {
+		rd.Meta.Debug = nil
+	}
+
+	var body bytes.Buffer
+	if err := t.ExecuteTemplate(&body, name, rd); err != nil {
+		return err
+	}
+	return r.sendBody(code, "text/html; charset=utf-8", body.Bytes(), func() error {
+		return r.a.BytesSender(code, "text/html; charset=utf-8", body.Bytes())
+	})
+}
+
+// Render executes the named template loaded with Client.LoadTemplates and sends it with status 200 OK.
+// Templates receive RenderData, so the reply data is available as .Data.
+// Returns ErrNoTemplates if no templates are loaded and ErrTemplateNotFound if the page does not exist.
+//
+// Example:
+//
+//	rp.Success(user).Render("users/show") // <h1>{{.Data.Name}}</h1> -> <h1>Jane</h1>
+func (r *Reply) Render(name string) error {
+	return r.renderTemplate(http.StatusOK, name)
+}
+
+// RenderStatus executes the named template like Render, with the specified status code.
+//
+// Example:
+//
+//	rp.Success(form).RenderStatus(http.StatusUnprocessableEntity, "users/new")
+func (r *Reply) RenderStatus(code int, name string) error {
+	return r.renderTemplate(code, name)
+}
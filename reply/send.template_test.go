@@ -0,0 +1,172 @@
+package reply_test
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/chesta132/goreply/reply"
+	"github.com/chesta132/goreply/reply/replytest"
+)
+
+// views returns a template file system with a layout, a partial and pages defining the same block.
+func views() fstest.MapFS {
+	return fstest.MapFS{
+		"layouts/main.html":  {Data: []byte(`<main>{{block "content" .}}{{end}}</main>`)},
+		"partials/path.html": {Data: []byte(`<p>{{.Request.Method}} {{.Request.Path}}?{{.Request.Query.Encode}}</p>`)},
+		"users/show.html":    {Data: []byte(`{{define "content"}}<h1>{{upper .Data.Name}}</h1>{{template "partials/path" .}}{{end}}`)},
+		"users/list.html":    {Data: []byte(`{{define "content"}}{{range .Data}}<li>{{.Name}}</li>{{end}}{{end}}`)},
+		"about.html":         {Data: []byte(`<p>{{.Data}}</p>`)},
+		"debug.html":         {Data: []byte(`{{define "content"}}[{{.Meta.Status}} {{.Meta.Debug}}]{{end}}`)},
+		"README.md":          {Data: []byte(`not a template`)},
+	}
+}
+
+func TestReplyRender(t *testing.T) {
+	funcs := template.FuncMap{"upper": strings.ToUpper}
+
+	tests := []struct {
+		name   string
+		client reply.Client
+		opts   reply.TemplateOptions
+		send   func(rp *reply.Reply) error
+		status int
+		body   string
+	}{
+		{
+			name: "LayoutPartialFuncs",
+			opts: reply.TemplateOptions{Layout: "layouts/main", Funcs: funcs},
+			send: func(rp *reply.Reply) error {
+				return rp.Success(streamItem{Name: "Jane"}).Render("users/show")
+			},
+			status: http.StatusOK,
+			body:   "<main><h1>JANE</h1><p>GET /users/42?tab=info</p></main>",
+		},
+		{
+			name: "SameBlockOtherPage",
+			opts: reply.TemplateOptions{Layout: "layouts/main", Funcs: funcs},
+			send: func(rp *reply.Reply) error {
+				return rp.Success([]streamItem{{Name: "Jane"}, {Name: "<John>"}}).RenderStatus(http.StatusCreated, "users/list")
+			},
+			status: http.StatusCreated,
+			body:   "<main><li>Jane</li><li>&lt;John&gt;</li></main>",
+		},
+		{
+			name:   "WithoutLayout",
+			opts:   reply.TemplateOptions{Funcs: funcs},
+			send:   func(rp *reply.Reply) error { return rp.Success("<b>goreply</b>").Render("about") },
+			status: http.StatusOK,
+			body:   "<p>&lt;b&gt;goreply&lt;/b&gt;</p>",
+		},
+		{
+			name: "DebugStripped",
+			opts: reply.TemplateOptions{Layout: "layouts/main", Funcs: funcs},
+			send: func(rp *reply.Reply) error {
+				return rp.Success(nil).Debug("secret").Render("debug")
+			},
+			status: http.StatusOK,
+			body:   "<main>[SUCCESS ]</main>",
+		},
+		{
+			name:   "DebugMode",
+			client: reply.Client{DebugMode: true},
+			opts:   reply.TemplateOptions{Layout: "layouts/main", Funcs: funcs},
+			send: func(rp *reply.Reply) error {
+				return rp.Success(nil).Debug("secret").Render("debug")
+			},
+			status: http.StatusOK,
+			body:   "<main>[SUCCESS secret]</main>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(tt.client)
+			if err := client.LoadTemplates(views(), tt.opts); err != nil {
+				t.Fatalf("LoadTemplates: %v", err)
+			}
+			rp, rec := replytest.New(client, httptest.NewRequest(http.MethodGet, "/users/42?tab=info", nil))
+			if err := tt.send(rp); err != nil {
+				t.Fatalf("render: %v", err)
+			}
+
+			rec.AssertStatus(t, tt.status).AssertHeader(t, "Content-Type", "text/html; charset=utf-8")
+			if got := string(rec.Body()); got != tt.body {
+				t.Errorf("body = %q, want %q", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestReplyRenderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		load    bool
+		page    string
+		wantErr error
+	}{
+		{"NoTemplates", false, "users/show", reply.ErrNoTemplates},
+		{"NotFound", true, "users/missing", reply.ErrTemplateNotFound},
+		{"SharedIsNotAPage", true, "layouts/main", reply.ErrTemplateNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := reply.NewClient(reply.Client{})
+			if tt.load {
+				if err := client.LoadTemplates(views(), reply.TemplateOptions{Funcs: template.FuncMap{"upper": strings.ToUpper}}); err != nil {
+					t.Fatalf("LoadTemplates: %v", err)
+				}
+			}
+			rp, rec := replytest.New(client, nil)
+			if err := rp.Success(nil).Render(tt.page); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(rec.Body()) != 0 {
+				t.Errorf("body = %s, want nothing sent", rec.Body())
+			}
+		})
+	}
+}
+
+func TestLoadTemplatesParseError(t *testing.T) {
+	client := reply.NewClient(reply.Client{})
+	if err := client.LoadTemplates(views(), reply.TemplateOptions{Funcs: template.FuncMap{"upper": strings.ToUpper}}); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+
+	broken := views()
+	broken["users/show.html"] = &fstest.MapFile{Data: []byte(`{{define "content"}}{{.Data.Name}`)}
+	if err := client.LoadTemplates(broken, reply.TemplateOptions{Funcs: template.FuncMap{"upper": strings.ToUpper}}); err == nil {
+		t.Fatal("LoadTemplates of a broken template succeeded")
+	}
+
+	// the previously loaded templates are kept
+	rp, rec := replytest.New(client, nil)
+	if err := rp.Success([]streamItem{{Name: "Jane"}}).Render("users/list"); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	rec.AssertStatus(t, http.StatusOK)
+}
+
+func TestLoadTemplatesReload(t *testing.T) {
+	fsys := views()
+	client := reply.NewClient(reply.Client{})
+	opts := reply.TemplateOptions{Layout: "layouts/main", Reload: true, Funcs: template.FuncMap{"upper": strings.ToUpper}}
+	if err := client.LoadTemplates(fsys, opts); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+
+	fsys["users/list.html"] = &fstest.MapFile{Data: []byte(`{{define "content"}}{{len .Data}} users{{end}}`)}
+	rp, rec := replytest.New(client, nil)
+	if err := rp.Success([]streamItem{{Name: "Jane"}}).Render("users/list"); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got := string(rec.Body()); got != "<main>1 users</main>" {
+		t.Errorf("body = %q, want the reloaded template", got)
+	}
+}
@@ -14,7 +14,9 @@ func (r *Reply) replyText(code int) error {
 			logError(errors.New("DATA TYPE IS NOT STRING"), 2)
 			d = ""
 		}
-		return r.a.TextSender(code, d)
+		return r.sendBody(code, "text/plain; charset=utf-8", []byte(d), func() error {
+			return r.a.TextSender(code, d)
+		})
 	}, 2)
 }
 
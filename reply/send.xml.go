@@ -5,9 +5,7 @@ import "net/http"
 // ReplyXML sends an XML-formatted response with the specified status code.
 // Payload will be marshaled to XML automatically.
 func (r *Reply) replyXML(code int) error {
-	return r.send(func() error {
-		return r.a.XmlSender(code, r.Payload)
-	}, 2)
+	return r.replyEncoded(FormatXML, code)
 }
 
 // ReplyXML sends an XML-formatted response with the specified status code.
@@ -32,8 +30,11 @@ func (r *Reply) CreatedXML() error {
 
 // FailXML sends a XML response with an error status.
 // If code is provided, use it; otherwise, retrieve from CodeAliases
-// or default to 500.
+// or default to 500. Sends application/problem+xml if Client.ProblemDetails is enabled.
 func (r *Reply) FailXML(code ...int) error {
 	c, _ := r.retrieveStatusCode(code...)
+	if r.c.ProblemDetails {
+		return r.replyProblem(FormatXML, c)
+	}
 	return r.replyXML(c)
 }
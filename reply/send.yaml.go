@@ -0,0 +1,36 @@
+package reply
+
+import "net/http"
+
+// ReplyYAML sends a YAML-encoded response with the specified status code.
+// Requires an encoder registered for FormatYAML, e.g. with yamlencoder.Register.
+// Returns ErrEncoderNotFound otherwise.
+//
+// Example:
+//
+//	yamlencoder.Register(Client)
+//	rp.Success(Data{Msg: "ok"}).ReplyYAML(http.StatusOK)
+func (r *Reply) ReplyYAML(code int) error {
+	return r.replyEncoded(FormatYAML, code)
+}
+
+// OkYAML is a shortcut for ReplyYAML with status 200 OK.
+func (r *Reply) OkYAML() error {
+	return r.replyEncoded(FormatYAML, http.StatusOK)
+}
+
+// CreatedYAML sends status 201 Created with YAML body.
+func (r *Reply) CreatedYAML() error {
+	return r.replyEncoded(FormatYAML, http.StatusCreated)
+}
+
+// FailYAML sends a YAML response with an error status.
+// If code is provided, use it; otherwise, retrieve from CodeAliases
+// or default to 500. Renders problem details if Client.ProblemDetails is enabled.
+func (r *Reply) FailYAML(code ...int) error {
+	c, _ := r.retrieveStatusCode(code...)
+	if r.c.ProblemDetails {
+		return r.replyProblem(FormatYAML, c)
+	}
+	return r.replyEncoded(FormatYAML, c)
+}